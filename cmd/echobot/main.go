@@ -56,10 +56,15 @@ func main() {
 	}()
 
 	for msg := range bot.Messages() {
+		// stickers, photos and other messages without text can't be echoed
+		if msg.Text == "" {
+			continue
+		}
 		go func(msg *telegram.Message) {
-			// echo the message as *bold*
-			txt := "*" + msg.Text + "*"
-			_, err := bot.SendMessage(msg.Chat.ID, txt)
+			// echo the message in bold
+			var txt telegram.Text
+			txt.Bold(msg.Text)
+			_, err := bot.SendMessage(msg.Chat.ID, txt.String(), telegram.WithEntities(txt.Entities()), telegram.WithThreadOf(*msg))
 			if err != nil {
 				log.Printf("Error while sending message. Err: %v\n", err)
 			}
@@ -0,0 +1,231 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// BotCommand represents a bot command shown in the command menu of Telegram
+// clients.
+type BotCommand struct {
+	// Text of the command; 1-32 characters. Can contain only lowercase English
+	// letters, digits and underscores
+	Command string `json:"command"`
+
+	// Description of the command; 1-256 characters
+	Description string `json:"description"`
+}
+
+// BotCommandScope represents the scope to which bot commands are applied. Its
+// dynamic type is one of BotCommandScopeDefault,
+// BotCommandScopeAllPrivateChats, BotCommandScopeAllGroupChats,
+// BotCommandScopeAllChatAdministrators, BotCommandScopeChat,
+// BotCommandScopeChatAdministrators or BotCommandScopeChatMember.
+//
+// Telegram clients pick the commands of the narrowest scope which applies to
+// the chat, in the reverse order of the list above.
+type BotCommandScope interface {
+	botCommandScope()
+}
+
+// BotCommandScopeDefault represents the default scope of bot commands. Default
+// commands are used if no commands with a narrower scope are specified for
+// the user.
+type BotCommandScopeDefault struct{}
+
+// BotCommandScopeAllPrivateChats represents the scope of bot commands,
+// covering all private chats.
+type BotCommandScopeAllPrivateChats struct{}
+
+// BotCommandScopeAllGroupChats represents the scope of bot commands, covering
+// all group and supergroup chats.
+type BotCommandScopeAllGroupChats struct{}
+
+// BotCommandScopeAllChatAdministrators represents the scope of bot commands,
+// covering all group and supergroup chat administrators.
+type BotCommandScopeAllChatAdministrators struct{}
+
+// BotCommandScopeChat represents the scope of bot commands, covering a
+// specific chat.
+type BotCommandScopeChat struct {
+	// Unique identifier for the target chat
+	ChatID int64 `json:"chat_id"`
+}
+
+// BotCommandScopeChatAdministrators represents the scope of bot commands,
+// covering all administrators of a specific group or supergroup chat.
+type BotCommandScopeChatAdministrators struct {
+	// Unique identifier for the target chat
+	ChatID int64 `json:"chat_id"`
+}
+
+// BotCommandScopeChatMember represents the scope of bot commands, covering a
+// specific member of a group or supergroup chat.
+type BotCommandScopeChatMember struct {
+	// Unique identifier for the target chat
+	ChatID int64 `json:"chat_id"`
+
+	// Unique identifier of the target user
+	UserID int64 `json:"user_id"`
+}
+
+func (BotCommandScopeDefault) botCommandScope()               {}
+func (BotCommandScopeAllPrivateChats) botCommandScope()       {}
+func (BotCommandScopeAllGroupChats) botCommandScope()         {}
+func (BotCommandScopeAllChatAdministrators) botCommandScope() {}
+func (BotCommandScopeChat) botCommandScope()                  {}
+func (BotCommandScopeChatAdministrators) botCommandScope()    {}
+func (BotCommandScopeChatMember) botCommandScope()            {}
+
+// MarshalJSON implements json.Marshaler.
+func (s BotCommandScopeDefault) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+	}{"default"})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BotCommandScopeAllPrivateChats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+	}{"all_private_chats"})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BotCommandScopeAllGroupChats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+	}{"all_group_chats"})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BotCommandScopeAllChatAdministrators) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+	}{"all_chat_administrators"})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BotCommandScopeChat) MarshalJSON() ([]byte, error) {
+	type scope BotCommandScopeChat
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		scope
+	}{"chat", scope(s)})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BotCommandScopeChatAdministrators) MarshalJSON() ([]byte, error) {
+	type scope BotCommandScopeChatAdministrators
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		scope
+	}{"chat_administrators", scope(s)})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BotCommandScopeChatMember) MarshalJSON() ([]byte, error) {
+	type scope BotCommandScopeChatMember
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		scope
+	}{"chat_member", scope(s)})
+}
+
+// SetMyCommands changes the list of the bot's commands for the given scope
+// and user language. A nil scope means BotCommandScopeDefault. If
+// languageCode, a two-letter ISO 639-1 code, is empty, commands will be
+// applied to all users from the given scope, for whose language there are no
+// dedicated commands.
+func (b *Bot) SetMyCommands(commands []BotCommand, scope BotCommandScope, languageCode string) error {
+	const method = "setMyCommands"
+	params := url.Values{}
+	if commands == nil {
+		commands = []BotCommand{}
+	}
+	cmds, err := json.Marshal(commands)
+	if err != nil {
+		return err
+	}
+	params.Set("commands", string(cmds))
+	if err := mapCommandScope(&params, scope, languageCode); err != nil {
+		return err
+	}
+
+	var r response
+	err = b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// GetMyCommands retrieves the current list of the bot's commands for the
+// given scope and user language. A nil scope means BotCommandScopeDefault.
+func (b *Bot) GetMyCommands(scope BotCommandScope, languageCode string) ([]BotCommand, error) {
+	const method = "getMyCommands"
+	params := url.Values{}
+	if err := mapCommandScope(&params, scope, languageCode); err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		response
+		Commands []BotCommand `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.OK {
+		return nil, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Commands, nil
+}
+
+// DeleteMyCommands deletes the list of the bot's commands for the given scope
+// and user language. After deletion, higher level commands will be shown to
+// affected users. A nil scope means BotCommandScopeDefault.
+func (b *Bot) DeleteMyCommands(scope BotCommandScope, languageCode string) error {
+	const method = "deleteMyCommands"
+	params := url.Values{}
+	if err := mapCommandScope(&params, scope, languageCode); err != nil {
+		return err
+	}
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+func mapCommandScope(m *url.Values, scope BotCommandScope, languageCode string) error {
+	if scope != nil {
+		s, err := json.Marshal(scope)
+		if err != nil {
+			return err
+		}
+		m.Set("scope", string(s))
+	}
+
+	if languageCode != "" {
+		m.Set("language_code", languageCode)
+	}
+
+	return nil
+}
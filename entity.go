@@ -0,0 +1,142 @@
+package telegram
+
+import "unicode"
+
+// Types of message entities
+const (
+	EntityMention       = "mention"
+	EntityHashtag       = "hashtag"
+	EntityCashtag       = "cashtag"
+	EntityBotCommand    = "bot_command"
+	EntityURL           = "url"
+	EntityEmail         = "email"
+	EntityPhoneNumber   = "phone_number"
+	EntityBold          = "bold"
+	EntityItalic        = "italic"
+	EntityUnderline     = "underline"
+	EntityStrikethrough = "strikethrough"
+	EntitySpoiler       = "spoiler"
+	EntityBlockquote    = "blockquote"
+	EntityCode          = "code"
+	EntityPre           = "pre"
+	EntityTextLink      = "text_link"
+	EntityTextMention   = "text_mention"
+	EntityCustomEmoji   = "custom_emoji"
+)
+
+// EntityText returns the part of the message text which the entity covers. If
+// the message has no text, the entity is taken to be one of the caption
+// entities and the part of the caption is returned.
+//
+// Entity offsets and lengths are measured in UTF-16 code units, so the text
+// is sliced accordingly rather than by bytes.
+func (m Message) EntityText(e MessageEntity) string {
+	text := m.Text
+	if text == "" {
+		text = m.Caption
+	}
+	return utf16Slice(text, e.Offset, e.Length)
+}
+
+// AllEntities returns the entities of the message text, or the entities of the
+// caption if the message has no text.
+func (m Message) AllEntities() []MessageEntity {
+	if m.Text != "" {
+		return m.Entities
+	}
+	return m.CaptionEntities
+}
+
+// Mentions returns the mentions, such as “@username”, in the message text or
+// caption, including mentions of users without usernames.
+func (m Message) Mentions() []string {
+	return m.entityTexts(EntityMention, EntityTextMention)
+}
+
+// Hashtags returns the hashtags, such as “#hashtag”, in the message text or
+// caption.
+func (m Message) Hashtags() []string {
+	return m.entityTexts(EntityHashtag)
+}
+
+// URLs returns the URLs in the message text or caption. For clickable text
+// links, the URL of the link is returned instead of its text.
+func (m Message) URLs() []string {
+	var urls []string
+	for _, e := range m.AllEntities() {
+		switch e.Type {
+		case EntityURL:
+			urls = append(urls, m.EntityText(e))
+		case EntityTextLink:
+			urls = append(urls, e.URL)
+		}
+	}
+	return urls
+}
+
+// BotCommands returns the bot commands, such as “/start” or
+// “/start@jobs_bot”, in the message text or caption.
+func (m Message) BotCommands() []string {
+	return m.entityTexts(EntityBotCommand)
+}
+
+// entityTexts returns the texts of the entities with one of the given types,
+// in order of appearance.
+func (m Message) entityTexts(types ...string) []string {
+	var texts []string
+	for _, e := range m.AllEntities() {
+		for _, t := range types {
+			if e.Type == t {
+				texts = append(texts, m.EntityText(e))
+				break
+			}
+		}
+	}
+	return texts
+}
+
+// utf16Len returns the length of s in UTF-16 code units.
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16RuneLen(r)
+	}
+	return n
+}
+
+// utf16RuneLen returns the number of UTF-16 code units needed to encode r.
+func utf16RuneLen(r rune) int {
+	if r >= 0x10000 && r <= unicode.MaxRune {
+		return 2
+	}
+	return 1
+}
+
+// utf16Slice returns the part of s starting at offset with the given length,
+// both measured in UTF-16 code units. Out of range values are clamped.
+func utf16Slice(s string, offset, length int) string {
+	if offset < 0 {
+		length += offset
+		offset = 0
+	}
+	if length <= 0 {
+		return ""
+	}
+
+	start, end := -1, len(s)
+	pos := 0
+	for i, r := range s {
+		if start < 0 && pos >= offset {
+			start = i
+		}
+		if pos >= offset+length {
+			end = i
+			break
+		}
+		pos += utf16RuneLen(r)
+	}
+	if start < 0 {
+		return ""
+	}
+	return s[start:end]
+}
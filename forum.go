@@ -0,0 +1,259 @@
+package telegram
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Colors of forum topic icons. Topics can only be created with one of these
+// colors.
+const (
+	TopicColorBlue   = 0x6FB9F0
+	TopicColorYellow = 0xFFD67E
+	TopicColorViolet = 0xCB86DB
+	TopicColorGreen  = 0x8EEE98
+	TopicColorRose   = 0xFF93B2
+	TopicColorRed    = 0xFB6F5F
+)
+
+// ForumTopic represents a forum topic.
+type ForumTopic struct {
+	// Unique identifier of the forum topic
+	MessageThreadID int64 `json:"message_thread_id"`
+
+	// Name of the topic
+	Name string `json:"name"`
+
+	// Color of the topic icon in RGB format
+	IconColor int `json:"icon_color"`
+
+	// Optional. Unique identifier of the custom emoji shown as the topic icon
+	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+// ForumTopicCreated represents a service message about a new forum topic
+// created in the chat.
+type ForumTopicCreated struct {
+	// Name of the topic
+	Name string `json:"name"`
+
+	// Color of the topic icon in RGB format
+	IconColor int `json:"icon_color"`
+
+	// Optional. Unique identifier of the custom emoji shown as the topic icon
+	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+// ForumTopicEdited represents a service message about an edited forum topic.
+type ForumTopicEdited struct {
+	// Optional. New name of the topic, if it was edited
+	Name string `json:"name,omitempty"`
+
+	// Optional. New identifier of the custom emoji shown as the topic icon, if
+	// it was edited; an empty string if the icon was removed
+	IconCustomEmojiID *string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+// ForumTopicClosed represents a service message about a forum topic closed in
+// the chat.
+type ForumTopicClosed struct{}
+
+// ForumTopicReopened represents a service message about a forum topic
+// reopened in the chat.
+type ForumTopicReopened struct{}
+
+// GeneralForumTopicHidden represents a service message about the General
+// forum topic hidden in the chat.
+type GeneralForumTopicHidden struct{}
+
+// GeneralForumTopicUnhidden represents a service message about the General
+// forum topic unhidden in the chat.
+type GeneralForumTopicUnhidden struct{}
+
+// WithThreadOf returns a SendOption which sends the message to the forum
+// topic the given message belongs to, so that replies go back to the topic
+// they originate from. It has no effect if m is not a topic message.
+func WithThreadOf(m Message) SendOption {
+	return func(o *sendOptions) {
+		if m.IsTopicMessage {
+			o.messageThreadID = m.MessageThreadID
+		}
+	}
+}
+
+// CreateForumTopic creates a topic in a forum supergroup chat. Name,
+// IconColor and IconCustomEmojiID fields of the given topic are used to
+// configure the new topic, and IconColor must be one of the TopicColor
+// constants if set. The bot must be an administrator in the chat for this to
+// work and must have the CanManageTopics administrator right.
+func (b *Bot) CreateForumTopic(chatID int64, topic ForumTopic) (ForumTopic, error) {
+	const method = "createForumTopic"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("name", topic.Name)
+	if topic.IconColor != 0 {
+		params.Set("icon_color", strconv.Itoa(topic.IconColor))
+	}
+
+	if topic.IconCustomEmojiID != "" {
+		params.Set("icon_custom_emoji_id", topic.IconCustomEmojiID)
+	}
+
+	var r struct {
+		response
+		Topic ForumTopic `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return ForumTopic{}, err
+	}
+
+	if !r.OK {
+		return ForumTopic{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Topic, nil
+}
+
+// EditForumTopic edits the name and icon of a topic in a forum supergroup
+// chat. An empty name keeps the current name. A nil iconCustomEmojiID keeps
+// the current icon, and a pointer to an empty string removes the icon. The
+// bot must be an administrator in the chat for this to work and must have the
+// CanManageTopics administrator right, unless it is the creator of the topic.
+func (b *Bot) EditForumTopic(chatID, messageThreadID int64, name string, iconCustomEmojiID *string) error {
+	const method = "editForumTopic"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("message_thread_id", strconv.FormatInt(messageThreadID, 10))
+	if name != "" {
+		params.Set("name", name)
+	}
+
+	if iconCustomEmojiID != nil {
+		params.Set("icon_custom_emoji_id", *iconCustomEmojiID)
+	}
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// CloseForumTopic closes an open topic in a forum supergroup chat. The bot
+// must be an administrator in the chat for this to work and must have the
+// CanManageTopics administrator right, unless it is the creator of the topic.
+func (b *Bot) CloseForumTopic(chatID, messageThreadID int64) error {
+	return b.forumTopicCommand("closeForumTopic", chatID, messageThreadID)
+}
+
+// ReopenForumTopic reopens a closed topic in a forum supergroup chat. The bot
+// must be an administrator in the chat for this to work and must have the
+// CanManageTopics administrator right, unless it is the creator of the topic.
+func (b *Bot) ReopenForumTopic(chatID, messageThreadID int64) error {
+	return b.forumTopicCommand("reopenForumTopic", chatID, messageThreadID)
+}
+
+// DeleteForumTopic deletes a forum topic along with all its messages in a
+// forum supergroup chat. The bot must be an administrator in the chat for
+// this to work and must have the CanDeleteMessages administrator right.
+func (b *Bot) DeleteForumTopic(chatID, messageThreadID int64) error {
+	return b.forumTopicCommand("deleteForumTopic", chatID, messageThreadID)
+}
+
+// UnpinAllForumTopicMessages clears the list of pinned messages in a forum
+// topic. The bot must be an administrator in the chat for this to work and
+// must have the CanPinMessages administrator right in the supergroup.
+func (b *Bot) UnpinAllForumTopicMessages(chatID, messageThreadID int64) error {
+	return b.forumTopicCommand("unpinAllForumTopicMessages", chatID, messageThreadID)
+}
+
+// EditGeneralForumTopic changes the name of the General topic in a forum
+// supergroup chat. The bot must be an administrator in the chat for this to
+// work and must have the CanManageTopics administrator right.
+func (b *Bot) EditGeneralForumTopic(chatID int64, name string) error {
+	const method = "editGeneralForumTopic"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("name", name)
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// CloseGeneralForumTopic closes the open General topic in a forum supergroup
+// chat. The bot must be an administrator in the chat for this to work and
+// must have the CanManageTopics administrator right.
+func (b *Bot) CloseGeneralForumTopic(chatID int64) error {
+	return b.forumTopicCommand("closeGeneralForumTopic", chatID, 0)
+}
+
+// ReopenGeneralForumTopic reopens the closed General topic in a forum
+// supergroup chat. The topic is unhidden if it was hidden. The bot must be an
+// administrator in the chat for this to work and must have the
+// CanManageTopics administrator right.
+func (b *Bot) ReopenGeneralForumTopic(chatID int64) error {
+	return b.forumTopicCommand("reopenGeneralForumTopic", chatID, 0)
+}
+
+// HideGeneralForumTopic hides the General topic in a forum supergroup chat.
+// The topic is closed if it was open. The bot must be an administrator in the
+// chat for this to work and must have the CanManageTopics administrator
+// right.
+func (b *Bot) HideGeneralForumTopic(chatID int64) error {
+	return b.forumTopicCommand("hideGeneralForumTopic", chatID, 0)
+}
+
+// UnhideGeneralForumTopic unhides the General topic in a forum supergroup
+// chat. The bot must be an administrator in the chat for this to work and
+// must have the CanManageTopics administrator right.
+func (b *Bot) UnhideGeneralForumTopic(chatID int64) error {
+	return b.forumTopicCommand("unhideGeneralForumTopic", chatID, 0)
+}
+
+// UnpinAllGeneralForumTopicMessages clears the list of pinned messages in the
+// General topic of a forum supergroup chat. The bot must be an administrator
+// in the chat for this to work and must have the CanPinMessages administrator
+// right in the supergroup.
+func (b *Bot) UnpinAllGeneralForumTopicMessages(chatID int64) error {
+	return b.forumTopicCommand("unpinAllGeneralForumTopicMessages", chatID, 0)
+}
+
+// forumTopicCommand calls one of the methods which act on a forum topic. The
+// General topic methods take no topic identifier, so messageThreadID is
+// omitted if it is 0.
+func (b *Bot) forumTopicCommand(method string, chatID, messageThreadID int64) error {
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	if messageThreadID != 0 {
+		params.Set("message_thread_id", strconv.FormatInt(messageThreadID, 10))
+	}
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
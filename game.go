@@ -0,0 +1,267 @@
+package telegram
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Game represents a game. Games are created via @BotFather and are identified
+// by their short names.
+type Game struct {
+	// Title of the game
+	Title string `json:"title"`
+
+	// Description of the game
+	Description string `json:"description"`
+
+	// Photo that will be displayed in the game message in chats
+	Photo []Photo `json:"photo"`
+
+	// Optional. Brief description of the game or high scores included in the
+	// game message. Can be automatically edited to include current high scores
+	// for the game when the bot calls SetGameScore, or manually edited
+	Text string `json:"text,omitempty"`
+
+	// Optional. Special entities that appear in text, such as usernames, URLs,
+	// bot commands, etc.
+	TextEntities []MessageEntity `json:"text_entities,omitempty"`
+
+	// Optional. Animation that will be displayed in the game message in chats
+	Animation *Animation `json:"animation,omitempty"`
+}
+
+// CallbackGame is a placeholder for the game launched by an inline keyboard
+// button. It holds no information.
+type CallbackGame struct{}
+
+// GameHighScore represents one row of the high scores table for a game.
+type GameHighScore struct {
+	// Position in high score table for the game
+	Position int `json:"position"`
+
+	// User
+	User User `json:"user"`
+
+	// Score
+	Score int `json:"score"`
+}
+
+// CallbackQuery represents an incoming callback query from a button in an
+// inline keyboard. If the button that originated the query was attached to a
+// message sent by the bot, the Message field is present. If the button was
+// attached to a message sent via the bot in inline mode, the InlineMessageID
+// field is present. Exactly one of the fields Data or GameShortName is
+// present.
+type CallbackQuery struct {
+	// Unique identifier for this query
+	ID string `json:"id"`
+
+	// Sender
+	From User `json:"from"`
+
+	// Optional. Message sent by the bot with the callback button that
+	// originated the query
+	Message *Message `json:"message,omitempty"`
+
+	// Optional. Identifier of the message sent via the bot in inline mode,
+	// that originated the query
+	InlineMessageID string `json:"inline_message_id,omitempty"`
+
+	// Global identifier, uniquely corresponding to the chat to which the
+	// message with the callback button was sent. Useful for high scores in
+	// games
+	ChatInstance string `json:"chat_instance"`
+
+	// Optional. Data associated with the callback button
+	Data string `json:"data,omitempty"`
+
+	// Optional. Short name of a game to be returned, serves as the unique
+	// identifier for the game
+	GameShortName string `json:"game_short_name,omitempty"`
+}
+
+// IsGame reports whether the query was originated by a game button, in which
+// case the game URL is expected in the answer.
+func (q CallbackQuery) IsGame() bool { return q.GameShortName != "" }
+
+// CallbackAnswer is the answer to a callback query. The answer is shown to
+// the user as a notification at the top of the chat screen or as an alert.
+type CallbackAnswer struct {
+	// Optional. Text of the notification. If empty, nothing will be shown to
+	// the user, 0-200 characters
+	Text string
+
+	// Optional. If true, an alert will be shown by the client instead of a
+	// notification at the top of the chat screen
+	ShowAlert bool
+
+	// Optional. URL that will be opened by the user's client. For game
+	// buttons, it is the URL of the game
+	URL string
+
+	// Optional. The maximum amount of time in seconds that the result of the
+	// callback query may be cached client-side
+	CacheTime int
+}
+
+// AnswerCallbackQuery sends an answer to a callback query sent from an inline
+// keyboard. The answer must be sent even if there is nothing to notify the
+// user about, otherwise a progress bar is shown on the button.
+func (b *Bot) AnswerCallbackQuery(queryID string, answer CallbackAnswer) error {
+	const method = "answerCallbackQuery"
+	params := url.Values{}
+	params.Set("callback_query_id", queryID)
+	if answer.Text != "" {
+		params.Set("text", answer.Text)
+	}
+
+	if answer.ShowAlert {
+		params.Set("show_alert", "true")
+	}
+
+	if answer.URL != "" {
+		params.Set("url", answer.URL)
+	}
+
+	if answer.CacheTime > 0 {
+		params.Set("cache_time", strconv.Itoa(answer.CacheTime))
+	}
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// SendGame sends the game with the given short name. If no reply markup is
+// set, a Play button which launches the game is shown. Otherwise, the first
+// button of the inline keyboard must be a button with a CallbackGame.
+func (b *Bot) SendGame(recipient int64, gameShortName string, opts ...SendOption) (Message, error) {
+	const method = "sendGame"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(recipient, 10))
+	params.Set("game_short_name", gameShortName)
+
+	mapSendOptions(&params, opts...)
+
+	var r struct {
+		response
+		Message Message `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return Message{}, err
+	}
+
+	if !r.OK {
+		return Message{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Message, nil
+}
+
+// SetGameScore sets the score of the user in the game sent by the bot in the
+// given message, and returns the edited message. Unless force is true, an
+// error is returned if the new score is not greater than the user's current
+// score. If disableEditMessage is true, the game message is not edited to
+// include the current scoreboard.
+func (b *Bot) SetGameScore(chatID, messageID, userID int64, score int, force, disableEditMessage bool) (Message, error) {
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("message_id", strconv.FormatInt(messageID, 10))
+
+	var r struct {
+		response
+		Message Message `json:"result"`
+	}
+	err := b.setGameScore(params, userID, score, force, disableEditMessage, &r)
+	if err != nil {
+		return Message{}, err
+	}
+
+	if !r.OK {
+		return Message{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Message, nil
+}
+
+// SetInlineGameScore is like SetGameScore, but for games sent via the bot in
+// inline mode, which are identified by their inline message ID.
+func (b *Bot) SetInlineGameScore(inlineMessageID string, userID int64, score int, force, disableEditMessage bool) error {
+	params := url.Values{}
+	params.Set("inline_message_id", inlineMessageID)
+
+	var r response
+	err := b.setGameScore(params, userID, score, force, disableEditMessage, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// GetGameHighScores retrieves the high scores table of the game sent by the
+// bot in the given message. The score of the given user and several of their
+// neighbors are returned.
+func (b *Bot) GetGameHighScores(chatID, messageID, userID int64) ([]GameHighScore, error) {
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("message_id", strconv.FormatInt(messageID, 10))
+	return b.getGameHighScores(params, userID)
+}
+
+// GetInlineGameHighScores is like GetGameHighScores, but for games sent via
+// the bot in inline mode, which are identified by their inline message ID.
+func (b *Bot) GetInlineGameHighScores(inlineMessageID string, userID int64) ([]GameHighScore, error) {
+	params := url.Values{}
+	params.Set("inline_message_id", inlineMessageID)
+	return b.getGameHighScores(params, userID)
+}
+
+func (b *Bot) setGameScore(params url.Values, userID int64, score int, force, disableEditMessage bool, v interface{}) error {
+	const method = "setGameScore"
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+	params.Set("score", strconv.Itoa(score))
+	if force {
+		params.Set("force", "true")
+	}
+
+	if disableEditMessage {
+		params.Set("disable_edit_message", "true")
+	}
+
+	return b.sendCommand(nil, method, params, v)
+}
+
+func (b *Bot) getGameHighScores(params url.Values, userID int64) ([]GameHighScore, error) {
+	const method = "getGameHighScores"
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+
+	var r struct {
+		response
+		Scores []GameHighScore `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.OK {
+		return nil, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Scores, nil
+}
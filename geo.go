@@ -0,0 +1,84 @@
+package telegram
+
+import "math"
+
+// earthRadius is the mean radius of the Earth in meters.
+const earthRadius = 6371008.8
+
+// Distance returns the great-circle distance between l and to in meters,
+// using the haversine formula.
+func (l Location) Distance(to Location) float64 {
+	lat1 := radians(l.Lat)
+	lat2 := radians(to.Lat)
+	dlat := lat2 - lat1
+	dlong := radians(to.Long - l.Long)
+
+	a := math.Sin(dlat/2)*math.Sin(dlat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dlong/2)*math.Sin(dlong/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Bearing returns the initial bearing from l to to in degrees, clockwise from
+// north, in the range [0, 360).
+func (l Location) Bearing(to Location) float64 {
+	lat1 := radians(l.Lat)
+	lat2 := radians(to.Lat)
+	dlong := radians(to.Long - l.Long)
+
+	y := math.Sin(dlong) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dlong)
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// Geofence is a geographic area.
+type Geofence interface {
+	// Contains reports whether the location is inside the area.
+	Contains(Location) bool
+}
+
+// Circle is a circular Geofence.
+type Circle struct {
+	// Center of the circle
+	Center Location
+
+	// Radius of the circle in meters
+	Radius float64
+}
+
+// Contains implements Geofence.
+func (c Circle) Contains(l Location) bool {
+	return c.Center.Distance(l) <= c.Radius
+}
+
+// Polygon is a polygonal Geofence given by its vertices in order. The
+// polygon is closed implicitly. Edges are treated as straight lines on the
+// latitude/longitude plane, which is accurate enough for areas up to a few
+// kilometers across. Polygons crossing the 180th meridian are not supported.
+// A location on an edge shared by two polygons is inside only one of them.
+type Polygon []Location
+
+// Contains implements Geofence.
+func (p Polygon) Contains(l Location) bool {
+	if len(p) < 3 {
+		return false
+	}
+
+	// ray casting: count the edges crossed by a ray going east from l.
+	inside := false
+	j := len(p) - 1
+	for i := range p {
+		a, b := p[i], p[j]
+		if (a.Lat > l.Lat) != (b.Lat > l.Lat) {
+			long := a.Long + (l.Lat-a.Lat)*(b.Long-a.Long)/(b.Lat-a.Lat)
+			if l.Long < long {
+				inside = !inside
+			}
+		}
+		j = i
+	}
+	return inside
+}
@@ -0,0 +1,117 @@
+package telegram
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	// length of a degree along a great circle
+	const degree = 2 * math.Pi * earthRadius / 360
+
+	tests := []struct {
+		name     string
+		from, to Location
+		want     float64
+	}{
+		{"same point", Location{Lat: 41.0082, Long: 28.9784}, Location{Lat: 41.0082, Long: 28.9784}, 0},
+		{"along equator", Location{Lat: 0, Long: 0}, Location{Lat: 0, Long: 1}, degree},
+		{"along meridian", Location{Lat: 10, Long: 20}, Location{Lat: 11, Long: 20}, degree},
+		{"antimeridian", Location{Lat: 0, Long: 179.5}, Location{Lat: 0, Long: -179.5}, degree},
+		{"antipodes", Location{Lat: 0, Long: 0}, Location{Lat: 0, Long: 180}, 180 * degree},
+		{"poles", Location{Lat: 90, Long: 0}, Location{Lat: -90, Long: 0}, 180 * degree},
+		{"istanbul ankara", Location{Lat: 41.0082, Long: 28.9784}, Location{Lat: 39.9334, Long: 32.8597}, 349356.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.from.Distance(tt.to)
+			if math.Abs(got-tt.want) > 1 {
+				t.Errorf("Distance = %v, want %v", got, tt.want)
+			}
+			if back := tt.to.Distance(tt.from); math.Abs(back-got) > 1e-6 {
+				t.Errorf("Distance back = %v, want %v", back, got)
+			}
+		})
+	}
+}
+
+func TestBearing(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to Location
+		want     float64
+	}{
+		{"north", Location{Lat: 0, Long: 0}, Location{Lat: 1, Long: 0}, 0},
+		{"east", Location{Lat: 0, Long: 0}, Location{Lat: 0, Long: 1}, 90},
+		{"south", Location{Lat: 0, Long: 0}, Location{Lat: -1, Long: 0}, 180},
+		{"west", Location{Lat: 0, Long: 0}, Location{Lat: 0, Long: -1}, 270},
+		{"east across antimeridian", Location{Lat: 0, Long: 179.5}, Location{Lat: 0, Long: -179.5}, 90},
+		{"west across antimeridian", Location{Lat: 0, Long: -179.5}, Location{Lat: 0, Long: 179.5}, 270},
+		{"northeast", Location{Lat: 0, Long: 0}, Location{Lat: 1, Long: 1}, 45},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.Bearing(tt.to); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("Bearing = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircleContains(t *testing.T) {
+	tests := []struct {
+		name   string
+		circle Circle
+		l      Location
+		want   bool
+	}{
+		{"center", Circle{Center: Location{Lat: 41, Long: 29}, Radius: 100}, Location{Lat: 41, Long: 29}, true},
+		{"inside", Circle{Center: Location{Lat: 0, Long: 0}, Radius: 100}, Location{Lat: 0.0008, Long: 0}, true},
+		{"outside", Circle{Center: Location{Lat: 0, Long: 0}, Radius: 100}, Location{Lat: 0.001, Long: 0}, false},
+		{"zero radius", Circle{Center: Location{Lat: 0, Long: 0}}, Location{Lat: 0.0001, Long: 0}, false},
+		{"across antimeridian", Circle{Center: Location{Lat: 0, Long: 179.9999}, Radius: 100}, Location{Lat: 0, Long: -179.9999}, true},
+		{"far across antimeridian", Circle{Center: Location{Lat: 0, Long: 179.9999}, Radius: 100}, Location{Lat: 0, Long: -179.99}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.circle.Contains(tt.l); got != tt.want {
+				t.Errorf("Contains(%+v) = %v, want %v", tt.l, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	square := Polygon{{Lat: 0, Long: 0}, {Lat: 0, Long: 1}, {Lat: 1, Long: 1}, {Lat: 1, Long: 0}}
+	east := Polygon{{Lat: 0, Long: 1}, {Lat: 0, Long: 2}, {Lat: 1, Long: 2}, {Lat: 1, Long: 1}}
+	concave := Polygon{{Lat: 0, Long: 0}, {Lat: 0, Long: 3}, {Lat: 3, Long: 3}, {Lat: 3, Long: 2}, {Lat: 1, Long: 2}, {Lat: 1, Long: 1}, {Lat: 3, Long: 1}, {Lat: 3, Long: 0}}
+	nearAntimeridian := Polygon{{Lat: 0, Long: 179}, {Lat: 0, Long: 180}, {Lat: 1, Long: 180}, {Lat: 1, Long: 179}}
+
+	tests := []struct {
+		name    string
+		polygon Polygon
+		l       Location
+		want    bool
+	}{
+		{"inside", square, Location{Lat: 0.5, Long: 0.5}, true},
+		{"outside", square, Location{Lat: 1.5, Long: 0.5}, false},
+		{"west edge", square, Location{Lat: 0.5, Long: 0}, true},
+		{"east edge", square, Location{Lat: 0.5, Long: 1}, false},
+		{"shared edge", east, Location{Lat: 0.5, Long: 1}, true},
+		{"south edge", square, Location{Lat: 0, Long: 0.5}, true},
+		{"north edge", square, Location{Lat: 1, Long: 0.5}, false},
+		{"concave notch", concave, Location{Lat: 2, Long: 1.5}, false},
+		{"concave arm", concave, Location{Lat: 2, Long: 2.5}, true},
+		{"concave base", concave, Location{Lat: 0.5, Long: 1.5}, true},
+		{"near antimeridian", nearAntimeridian, Location{Lat: 0.5, Long: 179.5}, true},
+		{"past antimeridian", nearAntimeridian, Location{Lat: 0.5, Long: -179.5}, false},
+		{"too few vertices", Polygon{{Lat: 0, Long: 0}, {Lat: 1, Long: 1}}, Location{Lat: 0.5, Long: 0.5}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.polygon.Contains(tt.l); got != tt.want {
+				t.Errorf("Contains(%+v) = %v, want %v", tt.l, got, tt.want)
+			}
+		})
+	}
+}
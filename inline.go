@@ -0,0 +1,694 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// InlineQuery represents an incoming inline query. When the user sends an
+// empty query, the bot could return some default or trending results.
+type InlineQuery struct {
+	// Unique identifier for this query
+	ID string `json:"id"`
+
+	// Sender
+	From User `json:"from"`
+
+	// Text of the query (up to 256 characters)
+	Query string `json:"query"`
+
+	// Offset of the results to be returned, can be controlled by the bot
+	Offset string `json:"offset"`
+
+	// Type of the chat from which the inline query was sent. Can be either
+	// “sender” for a private chat with the inline query sender, “private”,
+	// “group”, “supergroup”, or “channel”
+	ChatType string `json:"chat_type,omitempty"`
+
+	// Sender location, only for bots that request user location
+	Location *Location `json:"location,omitempty"`
+}
+
+// ChosenInlineResult represents a result of an inline query that was chosen
+// by the user and sent to their chat partner. Inline feedback must be enabled
+// via BotFather to receive these updates.
+type ChosenInlineResult struct {
+	// The unique identifier for the result that was chosen
+	ResultID string `json:"result_id"`
+
+	// The user that chose the result
+	From User `json:"from"`
+
+	// Sender location, only for bots that require user location
+	Location *Location `json:"location,omitempty"`
+
+	// Identifier of the sent inline message. Available only if there is an
+	// inline keyboard attached to the message
+	InlineMessageID string `json:"inline_message_id,omitempty"`
+
+	// The query that was used to obtain the result
+	Query string `json:"query"`
+}
+
+// InlineQueryResult represents one result of an inline query. Its dynamic
+// type is one of InlineQueryResultArticle, InlineQueryResultPhoto,
+// InlineQueryResultGif, InlineQueryResultVideo, InlineQueryResultAudio,
+// InlineQueryResultDocument, InlineQueryResultLocation,
+// InlineQueryResultVenue, InlineQueryResultContact or InlineQueryResultCached.
+type InlineQueryResult interface {
+	inlineQueryResult()
+}
+
+// InputMessageContent represents the content of a message to be sent as a
+// result of an inline query. Its dynamic type is one of
+// InputTextMessageContent, InputLocationMessageContent,
+// InputVenueMessageContent or InputContactMessageContent.
+type InputMessageContent interface {
+	inputMessageContent()
+}
+
+// InputTextMessageContent represents the content of a text message to be
+// sent as the result of an inline query.
+type InputTextMessageContent struct {
+	// Text of the message to be sent, 1-4096 characters
+	Text string `json:"message_text"`
+
+	// Mode for parsing entities in the message text
+	ParseMode ParseMode `json:"parse_mode,omitempty"`
+
+	// Special entities that appear in message text, which can be specified
+	// instead of ParseMode
+	Entities []MessageEntity `json:"entities,omitempty"`
+}
+
+func (InputTextMessageContent) inputMessageContent() {}
+
+// InputLocationMessageContent represents the content of a location message to
+// be sent as the result of an inline query.
+type InputLocationMessageContent struct {
+	Location
+}
+
+func (InputLocationMessageContent) inputMessageContent() {}
+
+// InputVenueMessageContent represents the content of a venue message to be
+// sent as the result of an inline query.
+type InputVenueMessageContent struct {
+	Location
+
+	// Name of the venue
+	Title string `json:"title"`
+
+	// Address of the venue
+	Address string `json:"address"`
+
+	// Foursquare identifier of the venue, if known
+	FoursquareID string `json:"foursquare_id,omitempty"`
+}
+
+func (InputVenueMessageContent) inputMessageContent() {}
+
+// InputContactMessageContent represents the content of a contact message to
+// be sent as the result of an inline query.
+type InputContactMessageContent struct {
+	// Contact's phone number
+	PhoneNumber string `json:"phone_number"`
+
+	// Contact's first name
+	FirstName string `json:"first_name"`
+
+	// Contact's last name
+	LastName string `json:"last_name,omitempty"`
+}
+
+func (InputContactMessageContent) inputMessageContent() {}
+
+// InlineQueryResultArticle represents a link to an article or web page.
+type InlineQueryResultArticle struct {
+	// Unique identifier for this result, 1-64 bytes
+	ID string `json:"id"`
+
+	// Title of the result
+	Title string `json:"title"`
+
+	// Content of the message to be sent
+	InputMessageContent InputMessageContent `json:"input_message_content"`
+
+	// URL of the result
+	URL string `json:"url,omitempty"`
+
+	// Short description of the result
+	Description string `json:"description,omitempty"`
+
+	// URL of the thumbnail for the result
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
+func (InlineQueryResultArticle) inlineQueryResult() {}
+
+// MarshalJSON implements json.Marshaler.
+func (r InlineQueryResultArticle) MarshalJSON() ([]byte, error) {
+	type result InlineQueryResultArticle
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		result
+	}{"article", result(r)})
+}
+
+// InlineQueryResultPhoto represents a link to a photo. By default, this photo
+// will be sent by the user with optional caption. Alternatively, you can use
+// InputMessageContent to send a message with the specified content instead
+// of the photo.
+type InlineQueryResultPhoto struct {
+	// Unique identifier for this result, 1-64 bytes
+	ID string `json:"id"`
+
+	// A valid URL of the photo. Photo must be in JPEG format. Photo size must
+	// not exceed 5MB
+	PhotoURL string `json:"photo_url"`
+
+	// URL of the thumbnail for the photo
+	ThumbnailURL string `json:"thumbnail_url"`
+
+	// Width of the photo
+	Width int `json:"photo_width,omitempty"`
+
+	// Height of the photo
+	Height int `json:"photo_height,omitempty"`
+
+	// Title for the result
+	Title string `json:"title,omitempty"`
+
+	// Short description of the result
+	Description string `json:"description,omitempty"`
+
+	// Caption of the photo to be sent, 0-1024 characters
+	Caption string `json:"caption,omitempty"`
+
+	// Mode for parsing entities in the photo caption
+	ParseMode ParseMode `json:"parse_mode,omitempty"`
+
+	// Content of the message to be sent instead of the photo
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+func (InlineQueryResultPhoto) inlineQueryResult() {}
+
+// MarshalJSON implements json.Marshaler.
+func (r InlineQueryResultPhoto) MarshalJSON() ([]byte, error) {
+	type result InlineQueryResultPhoto
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		result
+	}{"photo", result(r)})
+}
+
+// InlineQueryResultGif represents a link to an animated GIF file. By default,
+// this animated GIF file will be sent by the user with optional caption.
+type InlineQueryResultGif struct {
+	// Unique identifier for this result, 1-64 bytes
+	ID string `json:"id"`
+
+	// A valid URL for the GIF file. File size must not exceed 1MB
+	GifURL string `json:"gif_url"`
+
+	// Width of the GIF
+	Width int `json:"gif_width,omitempty"`
+
+	// Height of the GIF
+	Height int `json:"gif_height,omitempty"`
+
+	// Duration of the GIF in seconds
+	Duration int `json:"gif_duration,omitempty"`
+
+	// URL of the static (JPEG or GIF) or animated (MPEG4) thumbnail for the
+	// result
+	ThumbnailURL string `json:"thumbnail_url"`
+
+	// Title for the result
+	Title string `json:"title,omitempty"`
+
+	// Caption of the GIF file to be sent, 0-1024 characters
+	Caption string `json:"caption,omitempty"`
+
+	// Mode for parsing entities in the caption
+	ParseMode ParseMode `json:"parse_mode,omitempty"`
+
+	// Content of the message to be sent instead of the GIF animation
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+func (InlineQueryResultGif) inlineQueryResult() {}
+
+// MarshalJSON implements json.Marshaler.
+func (r InlineQueryResultGif) MarshalJSON() ([]byte, error) {
+	type result InlineQueryResultGif
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		result
+	}{"gif", result(r)})
+}
+
+// InlineQueryResultVideo represents a link to a page containing an embedded
+// video player or a video file. If an InlineQueryResultVideo message
+// contains an embedded video (e.g., YouTube), you must replace its content
+// using InputMessageContent.
+type InlineQueryResultVideo struct {
+	// Unique identifier for this result, 1-64 bytes
+	ID string `json:"id"`
+
+	// A valid URL for the embedded video player or video file
+	VideoURL string `json:"video_url"`
+
+	// MIME type of the content of the video URL, “text/html” or “video/mp4”
+	MimeType string `json:"mime_type"`
+
+	// URL of the thumbnail (JPEG only) for the video
+	ThumbnailURL string `json:"thumbnail_url"`
+
+	// Title for the result
+	Title string `json:"title"`
+
+	// Caption of the video to be sent, 0-1024 characters
+	Caption string `json:"caption,omitempty"`
+
+	// Mode for parsing entities in the video caption
+	ParseMode ParseMode `json:"parse_mode,omitempty"`
+
+	// Video width
+	Width int `json:"video_width,omitempty"`
+
+	// Video height
+	Height int `json:"video_height,omitempty"`
+
+	// Video duration in seconds
+	Duration int `json:"video_duration,omitempty"`
+
+	// Short description of the result
+	Description string `json:"description,omitempty"`
+
+	// Content of the message to be sent instead of the video
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+func (InlineQueryResultVideo) inlineQueryResult() {}
+
+// MarshalJSON implements json.Marshaler.
+func (r InlineQueryResultVideo) MarshalJSON() ([]byte, error) {
+	type result InlineQueryResultVideo
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		result
+	}{"video", result(r)})
+}
+
+// InlineQueryResultAudio represents a link to an MP3 audio file.
+type InlineQueryResultAudio struct {
+	// Unique identifier for this result, 1-64 bytes
+	ID string `json:"id"`
+
+	// A valid URL for the audio file
+	AudioURL string `json:"audio_url"`
+
+	// Title
+	Title string `json:"title"`
+
+	// Caption, 0-1024 characters
+	Caption string `json:"caption,omitempty"`
+
+	// Mode for parsing entities in the audio caption
+	ParseMode ParseMode `json:"parse_mode,omitempty"`
+
+	// Performer
+	Performer string `json:"performer,omitempty"`
+
+	// Audio duration in seconds
+	Duration int `json:"audio_duration,omitempty"`
+
+	// Content of the message to be sent instead of the audio
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+func (InlineQueryResultAudio) inlineQueryResult() {}
+
+// MarshalJSON implements json.Marshaler.
+func (r InlineQueryResultAudio) MarshalJSON() ([]byte, error) {
+	type result InlineQueryResultAudio
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		result
+	}{"audio", result(r)})
+}
+
+// InlineQueryResultDocument represents a link to a file. Currently, only .PDF
+// and .ZIP files can be sent using this method.
+type InlineQueryResultDocument struct {
+	// Unique identifier for this result, 1-64 bytes
+	ID string `json:"id"`
+
+	// Title for the result
+	Title string `json:"title"`
+
+	// Caption of the document to be sent, 0-1024 characters
+	Caption string `json:"caption,omitempty"`
+
+	// Mode for parsing entities in the document caption
+	ParseMode ParseMode `json:"parse_mode,omitempty"`
+
+	// A valid URL for the file
+	DocumentURL string `json:"document_url"`
+
+	// MIME type of the content of the file, either “application/pdf” or
+	// “application/zip”
+	MimeType string `json:"mime_type"`
+
+	// Short description of the result
+	Description string `json:"description,omitempty"`
+
+	// URL of the thumbnail (JPEG only) for the file
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+
+	// Content of the message to be sent instead of the file
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+func (InlineQueryResultDocument) inlineQueryResult() {}
+
+// MarshalJSON implements json.Marshaler.
+func (r InlineQueryResultDocument) MarshalJSON() ([]byte, error) {
+	type result InlineQueryResultDocument
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		result
+	}{"document", result(r)})
+}
+
+// InlineQueryResultLocation represents a location on a map.
+type InlineQueryResultLocation struct {
+	// Unique identifier for this result, 1-64 bytes
+	ID string `json:"id"`
+
+	Location
+
+	// Location title
+	Title string `json:"title"`
+
+	// URL of the thumbnail for the result
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+
+	// Content of the message to be sent instead of the location
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+func (InlineQueryResultLocation) inlineQueryResult() {}
+
+// MarshalJSON implements json.Marshaler.
+func (r InlineQueryResultLocation) MarshalJSON() ([]byte, error) {
+	type result InlineQueryResultLocation
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		result
+	}{"location", result(r)})
+}
+
+// InlineQueryResultVenue represents a venue.
+type InlineQueryResultVenue struct {
+	// Unique identifier for this result, 1-64 bytes
+	ID string `json:"id"`
+
+	Location
+
+	// Title of the venue
+	Title string `json:"title"`
+
+	// Address of the venue
+	Address string `json:"address"`
+
+	// Foursquare identifier of the venue if known
+	FoursquareID string `json:"foursquare_id,omitempty"`
+
+	// URL of the thumbnail for the result
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+
+	// Content of the message to be sent instead of the venue
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+func (InlineQueryResultVenue) inlineQueryResult() {}
+
+// MarshalJSON implements json.Marshaler.
+func (r InlineQueryResultVenue) MarshalJSON() ([]byte, error) {
+	type result InlineQueryResultVenue
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		result
+	}{"venue", result(r)})
+}
+
+// InlineQueryResultContact represents a contact with a phone number.
+type InlineQueryResultContact struct {
+	// Unique identifier for this result, 1-64 bytes
+	ID string `json:"id"`
+
+	// Contact's phone number
+	PhoneNumber string `json:"phone_number"`
+
+	// Contact's first name
+	FirstName string `json:"first_name"`
+
+	// Contact's last name
+	LastName string `json:"last_name,omitempty"`
+
+	// URL of the thumbnail for the result
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+
+	// Content of the message to be sent instead of the contact
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+func (InlineQueryResultContact) inlineQueryResult() {}
+
+// MarshalJSON implements json.Marshaler.
+func (r InlineQueryResultContact) MarshalJSON() ([]byte, error) {
+	type result InlineQueryResultContact
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		result
+	}{"contact", result(r)})
+}
+
+// CachedType is the type of a file stored on the Telegram servers, which can
+// be sent as an inline query result.
+type CachedType string
+
+// Types of cached files
+const (
+	CachedPhoto    CachedType = "photo"
+	CachedGif      CachedType = "gif"
+	CachedMpeg4Gif CachedType = "mpeg4_gif"
+	CachedSticker  CachedType = "sticker"
+	CachedDocument CachedType = "document"
+	CachedVideo    CachedType = "video"
+	CachedVoice    CachedType = "voice"
+	CachedAudio    CachedType = "audio"
+)
+
+// cachedFileIDKeys maps the types of cached files to the keys of their file
+// identifiers in inline query results.
+var cachedFileIDKeys = map[CachedType]string{
+	CachedPhoto:    "photo_file_id",
+	CachedGif:      "gif_file_id",
+	CachedMpeg4Gif: "mpeg4_file_id",
+	CachedSticker:  "sticker_file_id",
+	CachedDocument: "document_file_id",
+	CachedVideo:    "video_file_id",
+	CachedVoice:    "voice_file_id",
+	CachedAudio:    "audio_file_id",
+}
+
+// InlineQueryResultCached represents a link to a file stored on the Telegram
+// servers. Title is required for documents, videos and voice messages.
+// Stickers and audio files ignore Title, Description, Caption and ParseMode.
+type InlineQueryResultCached struct {
+	// Type of the cached file
+	Type CachedType
+
+	// Unique identifier for this result, 1-64 bytes
+	ID string
+
+	// A valid file identifier of the file
+	FileID string
+
+	// Title for the result
+	Title string
+
+	// Short description of the result
+	Description string
+
+	// Caption of the file to be sent, 0-1024 characters
+	Caption string
+
+	// Mode for parsing entities in the caption
+	ParseMode ParseMode
+
+	// Content of the message to be sent instead of the file
+	InputMessageContent InputMessageContent
+}
+
+func (InlineQueryResultCached) inlineQueryResult() {}
+
+// MarshalJSON implements json.Marshaler.
+func (r InlineQueryResultCached) MarshalJSON() ([]byte, error) {
+	key, ok := cachedFileIDKeys[r.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown cached file type: %q", r.Type)
+	}
+	m := map[string]interface{}{
+		"type": string(r.Type),
+		"id":   r.ID,
+		key:    r.FileID,
+	}
+	if r.Title != "" {
+		m["title"] = r.Title
+	}
+	if r.Description != "" {
+		m["description"] = r.Description
+	}
+	if r.Caption != "" {
+		m["caption"] = r.Caption
+	}
+	if r.ParseMode != ModeNone {
+		m["parse_mode"] = r.ParseMode
+	}
+	if r.InputMessageContent != nil {
+		m["input_message_content"] = r.InputMessageContent
+	}
+	return json.Marshal(m)
+}
+
+// InlineQueryResultsButton represents a button to be shown above inline query
+// results.
+type InlineQueryResultsButton struct {
+	// Label text on the button
+	Text string `json:"text"`
+
+	// Optional. Description of the Web App that will be launched when the user
+	// presses the button. The Web App will be able to switch back to the
+	// inline mode using switchInlineQuery. Exactly one of WebApp and
+	// StartParameter must be set
+	WebApp *WebAppInfo `json:"web_app,omitempty"`
+
+	// Optional. Deep-linking parameter for the /start message sent to the bot when a user
+	// presses the button. 1-64 characters, only A-Z, a-z, 0-9, _ and - are
+	// allowed
+	StartParameter string `json:"start_parameter,omitempty"`
+}
+
+// AnswerInlineQuery sends answers to an inline query. No more than 50 results
+// per query are allowed.
+func (b *Bot) AnswerInlineQuery(queryID string, results []InlineQueryResult, opts ...InlineOption) error {
+	const method = "answerInlineQuery"
+	params := url.Values{}
+	params.Set("inline_query_id", queryID)
+
+	if results == nil {
+		results = []InlineQueryResult{}
+	}
+	res, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	params.Set("results", string(res))
+
+	mapInlineOptions(&params, opts...)
+
+	var r response
+	err = b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// inlineOptions configure an AnswerInlineQuery call. inlineOptions are set by
+// the InlineOption values passed to AnswerInlineQuery.
+type inlineOptions struct {
+	cacheTime time.Duration
+
+	isPersonal bool
+
+	nextOffset string
+
+	button *InlineQueryResultsButton
+}
+
+// InlineOption configures how we answer an inline query.
+type InlineOption func(*inlineOptions)
+
+// WithCacheTime returns an InlineOption which sets the maximum amount of time
+// the result of the inline query may be cached on the server. Defaults to 300
+// seconds.
+func WithCacheTime(d time.Duration) InlineOption {
+	return func(o *inlineOptions) {
+		o.cacheTime = d
+	}
+}
+
+// WithPersonal returns an InlineOption which makes results cached on the
+// server side only for the user that sent the query. By default, results may
+// be returned to any user who sends the same query.
+func WithPersonal(personal bool) InlineOption {
+	return func(o *inlineOptions) {
+		o.isPersonal = personal
+	}
+}
+
+// WithNextOffset returns an InlineOption which sets the offset that a client
+// should send in the next query with the same text to receive more results.
+func WithNextOffset(offset string) InlineOption {
+	return func(o *inlineOptions) {
+		o.nextOffset = offset
+	}
+}
+
+// WithResultsButton returns an InlineOption which shows a button above inline
+// query results.
+func WithResultsButton(button InlineQueryResultsButton) InlineOption {
+	return func(o *inlineOptions) {
+		o.button = &button
+	}
+}
+
+func mapInlineOptions(m *url.Values, opts ...InlineOption) {
+	o := inlineOptions{cacheTime: -1}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	if o.cacheTime >= 0 {
+		m.Set("cache_time", strconv.Itoa(int(o.cacheTime/time.Second)))
+	}
+
+	if o.isPersonal {
+		m.Set("is_personal", "true")
+	}
+
+	if o.nextOffset != "" {
+		m.Set("next_offset", o.nextOffset)
+	}
+
+	if o.button != nil {
+		button, _ := json.Marshal(o.button)
+		m.Set("button", string(button))
+	}
+}
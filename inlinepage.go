@@ -0,0 +1,195 @@
+package telegram
+
+import (
+	"container/list"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxInlineResults is the maximum number of results allowed in a single
+// answer to an inline query.
+const maxInlineResults = 50
+
+// InlinePageFunc returns the results on the given page for an inline query.
+// Pages are numbered from 0. more reports whether there are pages after the
+// returned one. No more than 50 results are allowed on a page.
+type InlinePageFunc func(q InlineQuery, page int) (results []InlineQueryResult, more bool, err error)
+
+// InlinePaginator answers inline queries page by page. It encodes the next
+// page number into the offset of the answer, so that Telegram clients request
+// the following page when the user scrolls down, and caches recently served
+// pages per query text.
+//
+// A trivial example is:
+//
+//	p := telegram.NewInlinePaginator(bot, func(q telegram.InlineQuery, page int) ([]telegram.InlineQueryResult, bool, error) {
+//		return search(q.Query, page)
+//	})
+//	for u := range bot.Updates() {
+//		if u.InlineQuery != nil {
+//			p.Answer(*u.InlineQuery)
+//		}
+//	}
+type InlinePaginator struct {
+	// CacheSize is the maximum number of pages kept in the cache. If zero,
+	// pages are not cached.
+	CacheSize int
+
+	// CacheTTL is the duration a cached page stays valid.
+	CacheTTL time.Duration
+
+	// Personal makes the cache keyed by the user who sent the query in addition
+	// to the query text. It must be set if the results depend on the user.
+	// Answers are also marked as personal on Telegram side.
+	Personal bool
+
+	bot    *Bot
+	source InlinePageFunc
+
+	mu    sync.Mutex
+	lru   *list.List // of *inlinePage, most recently used first
+	pages map[inlinePageKey]*list.Element
+	now   func() time.Time
+}
+
+type inlinePageKey struct {
+	userID int64
+	query  string
+	page   int
+}
+
+type inlinePage struct {
+	key     inlinePageKey
+	results []InlineQueryResult
+	more    bool
+	expires time.Time
+}
+
+// NewInlinePaginator returns a new InlinePaginator which fetches pages from
+// source and answers queries through b. It caches up to 256 pages for 5
+// minutes by default.
+func NewInlinePaginator(b *Bot, source InlinePageFunc) *InlinePaginator {
+	return &InlinePaginator{
+		CacheSize: 256,
+		CacheTTL:  5 * time.Minute,
+		bot:       b,
+		source:    source,
+		lru:       list.New(),
+		pages:     make(map[inlinePageKey]*list.Element),
+		now:       time.Now,
+	}
+}
+
+// Answer answers the inline query with the page requested by its offset. An
+// empty or malformed offset requests the first page. opts are passed to
+// AnswerInlineQuery; the next offset is set by the paginator.
+func (p *InlinePaginator) Answer(q InlineQuery, opts ...InlineOption) error {
+	key := p.pageKey(q)
+	results, more, err := p.fetch(q, key)
+	if err != nil {
+		return err
+	}
+
+	var next string
+	if more {
+		next = encodeInlineOffset(key.page + 1)
+	}
+	opts = append(opts, WithNextOffset(next))
+	if p.Personal {
+		opts = append(opts, WithPersonal(true))
+	}
+
+	return p.bot.AnswerInlineQuery(q.ID, results, opts...)
+}
+
+// pageKey returns the cache key of the page requested by the inline query.
+func (p *InlinePaginator) pageKey(q InlineQuery) inlinePageKey {
+	key := inlinePageKey{query: q.Query, page: decodeInlineOffset(q.Offset)}
+	if p.Personal {
+		key.userID = q.From.ID
+	}
+	return key
+}
+
+// fetch returns the page identified by key, either from the cache or from the
+// source.
+func (p *InlinePaginator) fetch(q InlineQuery, key inlinePageKey) ([]InlineQueryResult, bool, error) {
+	if pg, ok := p.cached(key); ok {
+		return pg.results, pg.more, nil
+	}
+
+	results, more, err := p.source(q, key.page)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(results) > maxInlineResults {
+		return nil, false, fmt.Errorf("too many inline results on page %v: %v > %v", key.page, len(results), maxInlineResults)
+	}
+
+	p.store(&inlinePage{
+		key:     key,
+		results: results,
+		more:    more,
+		expires: p.now().Add(p.CacheTTL),
+	})
+	return results, more, nil
+}
+
+func (p *InlinePaginator) cached(key inlinePageKey) (*inlinePage, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, ok := p.pages[key]
+	if !ok {
+		return nil, false
+	}
+
+	pg := e.Value.(*inlinePage)
+	if p.now().After(pg.expires) {
+		p.lru.Remove(e)
+		delete(p.pages, key)
+		return nil, false
+	}
+
+	p.lru.MoveToFront(e)
+	return pg, true
+}
+
+func (p *InlinePaginator) store(pg *inlinePage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.CacheSize <= 0 {
+		return
+	}
+
+	if e, ok := p.pages[pg.key]; ok {
+		e.Value = pg
+		p.lru.MoveToFront(e)
+		return
+	}
+
+	p.pages[pg.key] = p.lru.PushFront(pg)
+	for p.lru.Len() > p.CacheSize {
+		e := p.lru.Back()
+		p.lru.Remove(e)
+		delete(p.pages, e.Value.(*inlinePage).key)
+	}
+}
+
+// encodeInlineOffset encodes page number into an inline query offset.
+func encodeInlineOffset(page int) string {
+	return strconv.FormatInt(int64(page), 36)
+}
+
+// decodeInlineOffset decodes the page number from an inline query offset. It
+// returns 0 for empty or malformed offsets.
+func decodeInlineOffset(offset string) int {
+	page, err := strconv.ParseInt(offset, 36, 32)
+	if err != nil || page < 0 {
+		return 0
+	}
+	return int(page)
+}
@@ -0,0 +1,142 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInlineOffset(t *testing.T) {
+	for _, page := range []int{0, 1, 35, 36, 1000, 1<<31 - 1} {
+		if got := decodeInlineOffset(encodeInlineOffset(page)); got != page {
+			t.Errorf("decodeInlineOffset(encodeInlineOffset(%v)) = %v", page, got)
+		}
+	}
+
+	tests := []struct {
+		offset string
+		want   int
+	}{
+		{"", 0},
+		{"1", 1},
+		{"z", 35},
+		{"10", 36},
+		{"-1", 0},
+		{"!", 0},
+		{"1.5", 0},
+		{"zzzzzzzzzzzzzzzz", 0},
+		{"offset=3", 0},
+	}
+	for _, tt := range tests {
+		if got := decodeInlineOffset(tt.offset); got != tt.want {
+			t.Errorf("decodeInlineOffset(%q) = %v, want %v", tt.offset, got, tt.want)
+		}
+	}
+}
+
+func TestInlinePaginatorCache(t *testing.T) {
+	type fetch struct {
+		user    int64
+		query   string
+		offset  string
+		advance time.Duration // clock advance before the fetch
+		fetched bool          // whether the source is called
+	}
+	tests := []struct {
+		name     string
+		size     int
+		personal bool
+		fetches  []fetch
+	}{
+		{
+			name: "cached",
+			size: 10,
+			fetches: []fetch{
+				{query: "a", fetched: true},
+				{query: "a", advance: time.Minute},
+				{query: "a", offset: "1", fetched: true},
+				{query: "b", fetched: true},
+				{user: 2, query: "a"},
+			},
+		},
+		{
+			name: "expired",
+			size: 10,
+			fetches: []fetch{
+				{query: "a", fetched: true},
+				{query: "a", advance: 5 * time.Minute},
+				{query: "a", advance: time.Second, fetched: true},
+				{query: "a"},
+			},
+		},
+		{
+			name: "evicted",
+			size: 2,
+			fetches: []fetch{
+				{query: "a", fetched: true},
+				{query: "b", fetched: true},
+				{query: "a"},
+				{query: "c", fetched: true},
+				{query: "a"},
+				{query: "b", fetched: true},
+				{query: "c", fetched: true},
+			},
+		},
+		{
+			name: "disabled",
+			size: 0,
+			fetches: []fetch{
+				{query: "a", fetched: true},
+				{query: "a", fetched: true},
+			},
+		},
+		{
+			name:     "personal",
+			size:     10,
+			personal: true,
+			fetches: []fetch{
+				{user: 1, query: "a", fetched: true},
+				{user: 2, query: "a", fetched: true},
+				{user: 1, query: "a"},
+				{user: 2, query: "a"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			calls := 0
+			p := NewInlinePaginator(nil, func(q InlineQuery, page int) ([]InlineQueryResult, bool, error) {
+				calls++
+				return nil, page < 3, nil
+			})
+			p.now = func() time.Time { return now }
+			p.CacheSize = tt.size
+			p.Personal = tt.personal
+
+			for i, f := range tt.fetches {
+				now = now.Add(f.advance)
+				q := InlineQuery{From: User{ID: f.user}, Query: f.query, Offset: f.offset}
+				before := calls
+				if _, _, err := p.fetch(q, p.pageKey(q)); err != nil {
+					t.Fatalf("fetch %v: %v", i, err)
+				}
+				if fetched := calls > before; fetched != f.fetched {
+					t.Errorf("fetch %v: source called = %v, want %v", i, fetched, f.fetched)
+				}
+			}
+		})
+	}
+}
+
+func TestInlinePaginatorTooManyResults(t *testing.T) {
+	p := NewInlinePaginator(nil, func(q InlineQuery, page int) ([]InlineQueryResult, bool, error) {
+		return make([]InlineQueryResult, maxInlineResults+1), false, nil
+	})
+	q := InlineQuery{Query: "a"}
+	if _, _, err := p.fetch(q, p.pageKey(q)); err == nil {
+		t.Errorf("fetch = nil error, want error")
+	}
+	if len(p.pages) != 0 {
+		t.Errorf("cached %v pages, want 0", len(p.pages))
+	}
+}
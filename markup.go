@@ -0,0 +1,49 @@
+package telegram
+
+import "strings"
+
+var (
+	markdownV2Escaper     = newEscaper(`\_*[]()~` + "`" + `>#+-=|{}.!`)
+	markdownV2CodeEscaper = newEscaper(`\` + "`")
+	markdownV2URLEscaper  = newEscaper(`\)`)
+
+	htmlEscaper = strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+		`"`, "&quot;",
+	)
+)
+
+// newEscaper returns a Replacer which escapes each of chars with a preceding
+// backslash.
+func newEscaper(chars string) *strings.Replacer {
+	var oldnew []string
+	for _, c := range chars {
+		oldnew = append(oldnew, string(c), `\`+string(c))
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+// EscapeMarkdownV2 escapes s so that it is displayed as is in a message
+// formatted with ModeMarkdownV2. It is suitable for plain text, including the
+// text inside bold, italic and other formatting entities, and the text of
+// links.
+func EscapeMarkdownV2(s string) string { return markdownV2Escaper.Replace(s) }
+
+// EscapeMarkdownV2Code escapes s for use inside inline code or a pre block in
+// a message formatted with ModeMarkdownV2.
+func EscapeMarkdownV2Code(s string) string { return markdownV2CodeEscaper.Replace(s) }
+
+// EscapeMarkdownV2URL escapes s for use as the URL part of an inline link,
+// inside the parentheses, in a message formatted with ModeMarkdownV2.
+func EscapeMarkdownV2URL(s string) string { return markdownV2URLEscaper.Replace(s) }
+
+// EscapeHTML escapes s so that it is displayed as is in a message formatted
+// with ModeHTML. It is suitable for plain text and the text inside tags,
+// including code and pre blocks.
+func EscapeHTML(s string) string { return htmlEscaper.Replace(s) }
+
+// EscapeHTMLURL escapes s for use as the href attribute of a link in a
+// message formatted with ModeHTML.
+func EscapeHTMLURL(s string) string { return htmlEscaper.Replace(s) }
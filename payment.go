@@ -0,0 +1,429 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CurrencyStars is the currency code of Telegram Stars. Payments in Telegram
+// Stars don't need a payment provider token and must have exactly one price.
+const CurrencyStars = "XTR"
+
+// LabeledPrice represents a portion of the price for goods or services.
+type LabeledPrice struct {
+	// Portion label
+	Label string `json:"label"`
+
+	// Price of the product in the smallest units of the currency (integer, not
+	// float/double). For example, for a price of US$ 1.45 pass amount = 145
+	Amount int `json:"amount"`
+}
+
+// Invoice contains basic information about an invoice. When sending an
+// invoice, the fields which are not part of the received invoice are used to
+// configure the payment.
+type Invoice struct {
+	// Product name, 1-32 characters
+	Title string `json:"title"`
+
+	// Product description, 1-255 characters
+	Description string `json:"description"`
+
+	// Unique bot deep-linking parameter that can be used to generate this
+	// invoice
+	StartParameter string `json:"start_parameter"`
+
+	// Three-letter ISO 4217 currency code, or “XTR” for payments in Telegram
+	// Stars
+	Currency string `json:"currency"`
+
+	// Total price in the smallest units of the currency
+	TotalAmount int `json:"total_amount"`
+
+	// Bot-defined invoice payload, 1-128 bytes. This will not be displayed to
+	// the user, use it for your internal processes
+	Payload string `json:"-"`
+
+	// Payment provider token, obtained via BotFather. Empty for payments in
+	// Telegram Stars
+	ProviderToken string `json:"-"`
+
+	// Price breakdown (e.g. product price, tax, discount, delivery cost,
+	// delivery tax, bonus, etc.). Must contain exactly one item for payments
+	// in Telegram Stars
+	Prices []LabeledPrice `json:"-"`
+
+	// The maximum accepted amount for tips in the smallest units of the
+	// currency. Not supported for payments in Telegram Stars
+	MaxTipAmount int `json:"-"`
+
+	// Suggested amounts of tips in the smallest units of the currency. At most
+	// 4 suggested tip amounts can be specified
+	SuggestedTipAmounts []int `json:"-"`
+
+	// JSON-serialized data about the invoice, which will be shared with the
+	// payment provider
+	ProviderData string `json:"-"`
+
+	// URL of the product photo for the invoice
+	PhotoURL string `json:"-"`
+
+	// Product photo dimensions
+	PhotoSize   int `json:"-"`
+	PhotoWidth  int `json:"-"`
+	PhotoHeight int `json:"-"`
+
+	// Information required from the user to complete the order
+	NeedName            bool `json:"-"`
+	NeedPhoneNumber     bool `json:"-"`
+	NeedEmail           bool `json:"-"`
+	NeedShippingAddress bool `json:"-"`
+
+	// Whether the user's phone number or email should be sent to the provider
+	SendPhoneNumberToProvider bool `json:"-"`
+	SendEmailToProvider       bool `json:"-"`
+
+	// Whether the final price depends on the shipping method
+	IsFlexible bool `json:"-"`
+
+	// The time after which the subscription will be renewed. Currently, it
+	// must always be 30 days. Only supported by CreateInvoiceLink for payments
+	// in Telegram Stars
+	SubscriptionPeriod time.Duration `json:"-"`
+}
+
+// ShippingAddress represents a shipping address.
+type ShippingAddress struct {
+	// Two-letter ISO 3166-1 alpha-2 country code
+	CountryCode string `json:"country_code"`
+
+	// State, if applicable
+	State string `json:"state"`
+
+	// City
+	City string `json:"city"`
+
+	// First line for the address
+	StreetLine1 string `json:"street_line1"`
+
+	// Second line for the address
+	StreetLine2 string `json:"street_line2"`
+
+	// Address post code
+	PostCode string `json:"post_code"`
+}
+
+// OrderInfo represents information about an order.
+type OrderInfo struct {
+	// User name
+	Name string `json:"name,omitempty"`
+
+	// User's phone number
+	PhoneNumber string `json:"phone_number,omitempty"`
+
+	// User email
+	Email string `json:"email,omitempty"`
+
+	// User shipping address
+	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
+}
+
+// ShippingOption represents one shipping option.
+type ShippingOption struct {
+	// Shipping option identifier
+	ID string `json:"id"`
+
+	// Option title
+	Title string `json:"title"`
+
+	// List of price portions
+	Prices []LabeledPrice `json:"prices"`
+}
+
+// ShippingQuery contains information about an incoming shipping query. It is
+// sent only for invoices with flexible price.
+type ShippingQuery struct {
+	// Unique query identifier
+	ID string `json:"id"`
+
+	// User who sent the query
+	From User `json:"from"`
+
+	// Bot specified invoice payload
+	InvoicePayload string `json:"invoice_payload"`
+
+	// User specified shipping address
+	ShippingAddress ShippingAddress `json:"shipping_address"`
+}
+
+// PreCheckoutQuery contains information about an incoming pre-checkout query.
+type PreCheckoutQuery struct {
+	// Unique query identifier
+	ID string `json:"id"`
+
+	// User who sent the query
+	From User `json:"from"`
+
+	// Three-letter ISO 4217 currency code, or “XTR” for payments in Telegram
+	// Stars
+	Currency string `json:"currency"`
+
+	// Total price in the smallest units of the currency
+	TotalAmount int `json:"total_amount"`
+
+	// Bot specified invoice payload
+	InvoicePayload string `json:"invoice_payload"`
+
+	// Identifier of the shipping option chosen by the user
+	ShippingOptionID string `json:"shipping_option_id,omitempty"`
+
+	// Order information provided by the user
+	OrderInfo *OrderInfo `json:"order_info,omitempty"`
+}
+
+// SuccessfulPayment contains basic information about a successful payment.
+type SuccessfulPayment struct {
+	// Three-letter ISO 4217 currency code, or “XTR” for payments in Telegram
+	// Stars
+	Currency string `json:"currency"`
+
+	// Total price in the smallest units of the currency
+	TotalAmount int `json:"total_amount"`
+
+	// Bot specified invoice payload
+	InvoicePayload string `json:"invoice_payload"`
+
+	// Expiration date of the subscription in Unix time, if the payment is for
+	// a subscription
+	SubscriptionExpirationDate int64 `json:"subscription_expiration_date,omitempty"`
+
+	// True, if the payment is a recurring payment for a subscription
+	IsRecurring bool `json:"is_recurring,omitempty"`
+
+	// True, if the payment is the first payment for a subscription
+	IsFirstRecurring bool `json:"is_first_recurring,omitempty"`
+
+	// Identifier of the shipping option chosen by the user
+	ShippingOptionID string `json:"shipping_option_id,omitempty"`
+
+	// Order information provided by the user
+	OrderInfo *OrderInfo `json:"order_info,omitempty"`
+
+	// Telegram payment identifier. Use it with RefundStarPayment for payments
+	// in Telegram Stars
+	TelegramPaymentChargeID string `json:"telegram_payment_charge_id"`
+
+	// Provider payment identifier
+	ProviderPaymentChargeID string `json:"provider_payment_charge_id"`
+}
+
+// SendInvoice sends an invoice to recipient.
+func (b *Bot) SendInvoice(recipient int64, invoice Invoice, opts ...SendOption) (Message, error) {
+	const method = "sendInvoice"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(recipient, 10))
+	if err := mapInvoice(&params, invoice); err != nil {
+		return Message{}, err
+	}
+
+	mapSendOptions(&params, opts...)
+
+	var r struct {
+		response
+		Message Message `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return Message{}, err
+	}
+
+	if !r.OK {
+		return Message{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Message, nil
+}
+
+// CreateInvoiceLink creates a link for an invoice.
+func (b *Bot) CreateInvoiceLink(invoice Invoice) (string, error) {
+	const method = "createInvoiceLink"
+	params := url.Values{}
+	if err := mapInvoice(&params, invoice); err != nil {
+		return "", err
+	}
+
+	if invoice.SubscriptionPeriod != 0 {
+		params.Set("subscription_period", strconv.Itoa(int(invoice.SubscriptionPeriod/time.Second)))
+	}
+
+	var r struct {
+		response
+		Link string `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return "", err
+	}
+
+	if !r.OK {
+		return "", fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Link, nil
+}
+
+// AnswerShippingQuery replies to a shipping query. If ok is true, options
+// lists the available shipping options. Otherwise, errorMessage explains in
+// human readable form why it is impossible to complete the order, which will
+// be displayed to the user.
+func (b *Bot) AnswerShippingQuery(queryID string, ok bool, options []ShippingOption, errorMessage string) error {
+	const method = "answerShippingQuery"
+	params := url.Values{}
+	params.Set("shipping_query_id", queryID)
+	params.Set("ok", strconv.FormatBool(ok))
+	if ok {
+		opts, err := json.Marshal(options)
+		if err != nil {
+			return err
+		}
+		params.Set("shipping_options", string(opts))
+	} else {
+		params.Set("error_message", errorMessage)
+	}
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// AnswerPreCheckoutQuery responds to a pre-checkout query. If ok is false,
+// errorMessage explains the reason for failure to proceed with the checkout,
+// which will be displayed to the user. The bot must reply within 10 seconds
+// after the pre-checkout query was received.
+func (b *Bot) AnswerPreCheckoutQuery(queryID string, ok bool, errorMessage string) error {
+	const method = "answerPreCheckoutQuery"
+	params := url.Values{}
+	params.Set("pre_checkout_query_id", queryID)
+	params.Set("ok", strconv.FormatBool(ok))
+	if !ok {
+		params.Set("error_message", errorMessage)
+	}
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// RefundStarPayment refunds a successful payment in Telegram Stars. chargeID
+// is the TelegramPaymentChargeID of the SuccessfulPayment.
+func (b *Bot) RefundStarPayment(userID int64, chargeID string) error {
+	const method = "refundStarPayment"
+	params := url.Values{}
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+	params.Set("telegram_payment_charge_id", chargeID)
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+func mapInvoice(m *url.Values, inv Invoice) error {
+	m.Set("title", inv.Title)
+	m.Set("description", inv.Description)
+	m.Set("payload", inv.Payload)
+	m.Set("currency", inv.Currency)
+
+	prices, err := json.Marshal(inv.Prices)
+	if err != nil {
+		return err
+	}
+	m.Set("prices", string(prices))
+
+	if inv.ProviderToken != "" {
+		m.Set("provider_token", inv.ProviderToken)
+	}
+
+	if inv.StartParameter != "" {
+		m.Set("start_parameter", inv.StartParameter)
+	}
+
+	if inv.MaxTipAmount != 0 {
+		m.Set("max_tip_amount", strconv.Itoa(inv.MaxTipAmount))
+	}
+
+	if len(inv.SuggestedTipAmounts) > 0 {
+		tips, err := json.Marshal(inv.SuggestedTipAmounts)
+		if err != nil {
+			return err
+		}
+		m.Set("suggested_tip_amounts", string(tips))
+	}
+
+	if inv.ProviderData != "" {
+		m.Set("provider_data", inv.ProviderData)
+	}
+
+	if inv.PhotoURL != "" {
+		m.Set("photo_url", inv.PhotoURL)
+		m.Set("photo_size", strconv.Itoa(inv.PhotoSize))
+		m.Set("photo_width", strconv.Itoa(inv.PhotoWidth))
+		m.Set("photo_height", strconv.Itoa(inv.PhotoHeight))
+	}
+
+	if inv.NeedName {
+		m.Set("need_name", "true")
+	}
+
+	if inv.NeedPhoneNumber {
+		m.Set("need_phone_number", "true")
+	}
+
+	if inv.NeedEmail {
+		m.Set("need_email", "true")
+	}
+
+	if inv.NeedShippingAddress {
+		m.Set("need_shipping_address", "true")
+	}
+
+	if inv.SendPhoneNumberToProvider {
+		m.Set("send_phone_number_to_provider", "true")
+	}
+
+	if inv.SendEmailToProvider {
+		m.Set("send_email_to_provider", "true")
+	}
+
+	if inv.IsFlexible {
+		m.Set("is_flexible", "true")
+	}
+
+	return nil
+}
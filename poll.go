@@ -0,0 +1,210 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PollType is the type of a poll.
+type PollType string
+
+// Types of polls
+const (
+	PollRegular PollType = "regular"
+	PollQuiz    PollType = "quiz"
+)
+
+// Poll contains information about a poll.
+type Poll struct {
+	// Unique poll identifier
+	ID string `json:"id"`
+
+	// Poll question, 1-300 characters
+	Question string `json:"question"`
+
+	// List of poll options
+	Options []PollOption `json:"options"`
+
+	// Total number of users that voted in the poll
+	TotalVoterCount int `json:"total_voter_count"`
+
+	// True, if the poll is closed
+	IsClosed bool `json:"is_closed"`
+
+	// True, if the poll is anonymous. Always set for received polls. When
+	// sending a poll, nil means Telegram's default, which is anonymous
+	IsAnonymous *bool `json:"is_anonymous,omitempty"`
+
+	// Poll type, currently can be “regular” or “quiz”
+	Type PollType `json:"type"`
+
+	// True, if the poll allows multiple answers
+	AllowsMultipleAnswers bool `json:"allows_multiple_answers"`
+
+	// 0-based identifier of the correct answer option. Available only for
+	// polls in the quiz mode, which are closed, or was sent (not forwarded) by
+	// the bot or to the private chat with the bot. nil if not available
+	CorrectOptionID *int `json:"correct_option_id,omitempty"`
+
+	// Text that is shown when a user chooses an incorrect answer or taps on the
+	// lamp icon in a quiz-style poll, 0-200 characters
+	Explanation string `json:"explanation,omitempty"`
+
+	// Special entities like usernames, URLs, bot commands, etc. that appear in
+	// the explanation
+	ExplanationEntities []MessageEntity `json:"explanation_entities,omitempty"`
+
+	// Amount of time in seconds the poll will be active after creation
+	OpenPeriod int `json:"open_period,omitempty"`
+
+	// Point in time (Unix timestamp) when the poll will be automatically
+	// closed
+	CloseDate int64 `json:"close_date,omitempty"`
+
+	// Mode for parsing entities in the explanation. Used only when sending a
+	// poll
+	ExplanationParseMode ParseMode `json:"-"`
+}
+
+// CloseTime returns the moment the poll will be automatically closed in UTC
+// time. It returns the zero time if the poll has no close date.
+func (p Poll) CloseTime() time.Time {
+	if p.CloseDate == 0 {
+		return time.Time{}
+	}
+	return time.Unix(p.CloseDate, 0).UTC()
+}
+
+// PollOption contains information about one answer option in a poll.
+type PollOption struct {
+	// Option text, 1-100 characters
+	Text string `json:"text"`
+
+	// Number of users that voted for this option
+	VoterCount int `json:"voter_count"`
+}
+
+// PollAnswer represents an answer of a user in a non-anonymous poll.
+type PollAnswer struct {
+	// Unique poll identifier
+	PollID string `json:"poll_id"`
+
+	// The chat that changed the answer to the poll, if the voter is anonymous
+	VoterChat *Chat `json:"voter_chat,omitempty"`
+
+	// The user that changed the answer to the poll, if the voter isn't
+	// anonymous
+	User User `json:"user,omitempty"`
+
+	// 0-based identifiers of chosen answer options. May be empty if the vote
+	// was retracted
+	OptionIDs []int `json:"option_ids"`
+}
+
+// IsRetracted reports whether the user retracted their vote.
+func (a PollAnswer) IsRetracted() bool { return len(a.OptionIDs) == 0 }
+
+// SendPoll sends a native poll to recipient. Question, Options, IsAnonymous,
+// Type, AllowsMultipleAnswers, CorrectOptionID, Explanation,
+// ExplanationParseMode, OpenPeriod, CloseDate and IsClosed fields of the poll
+// are used. Polls are anonymous unless IsAnonymous is set to false.
+// CorrectOptionID is required for quizzes and ignored otherwise.
+func (b *Bot) SendPoll(recipient int64, poll Poll, opts ...SendOption) (Message, error) {
+	const method = "sendPoll"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(recipient, 10))
+	params.Set("question", poll.Question)
+
+	type inputPollOption struct {
+		Text string `json:"text"`
+	}
+	options := make([]inputPollOption, 0, len(poll.Options))
+	for _, o := range poll.Options {
+		options = append(options, inputPollOption{Text: o.Text})
+	}
+	opt, err := json.Marshal(options)
+	if err != nil {
+		return Message{}, err
+	}
+	params.Set("options", string(opt))
+
+	if poll.IsAnonymous != nil {
+		params.Set("is_anonymous", strconv.FormatBool(*poll.IsAnonymous))
+	}
+
+	if poll.Type != "" {
+		params.Set("type", string(poll.Type))
+	}
+
+	if poll.AllowsMultipleAnswers {
+		params.Set("allows_multiple_answers", "true")
+	}
+
+	if poll.Type == PollQuiz && poll.CorrectOptionID != nil {
+		params.Set("correct_option_id", strconv.Itoa(*poll.CorrectOptionID))
+	}
+
+	if poll.Explanation != "" {
+		params.Set("explanation", poll.Explanation)
+	}
+
+	if poll.ExplanationParseMode != ModeNone {
+		params.Set("explanation_parse_mode", string(poll.ExplanationParseMode))
+	}
+
+	if poll.OpenPeriod != 0 {
+		params.Set("open_period", strconv.Itoa(poll.OpenPeriod))
+	}
+
+	if poll.CloseDate != 0 {
+		params.Set("close_date", strconv.FormatInt(poll.CloseDate, 10))
+	}
+
+	if poll.IsClosed {
+		params.Set("is_closed", "true")
+	}
+
+	mapSendOptions(&params, opts...)
+
+	var r struct {
+		response
+		Message Message `json:"result"`
+	}
+	err = b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return Message{}, err
+	}
+
+	if !r.OK {
+		return Message{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Message, nil
+}
+
+// StopPoll stops a poll which was sent by the bot and returns the stopped
+// poll.
+func (b *Bot) StopPoll(chatID, messageID int64) (Poll, error) {
+	const method = "stopPoll"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("message_id", strconv.FormatInt(messageID, 10))
+
+	var r struct {
+		response
+		Poll Poll `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return Poll{}, err
+	}
+
+	if !r.OK {
+		return Poll{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Poll, nil
+}
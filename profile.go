@@ -0,0 +1,238 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// MenuButtonType is the type of the bot's menu button.
+type MenuButtonType string
+
+// Types of menu buttons
+const (
+	MenuButtonDefault  MenuButtonType = "default"
+	MenuButtonCommands MenuButtonType = "commands"
+	MenuButtonWebApp   MenuButtonType = "web_app"
+)
+
+// MenuButton describes the bot's menu button in a private chat.
+type MenuButton struct {
+	// Type of the button. MenuButtonCommands opens the bot's list of commands,
+	// MenuButtonWebApp launches a Web App and MenuButtonDefault means that no
+	// specific value for the menu button was set
+	Type MenuButtonType `json:"type"`
+
+	// Text on the button, for Web App buttons only
+	Text string `json:"text,omitempty"`
+
+	// Description of the Web App that will be launched when the user presses
+	// the button, for Web App buttons only
+	WebApp *WebAppInfo `json:"web_app,omitempty"`
+}
+
+// WebAppInfo describes a Web App.
+type WebAppInfo struct {
+	// An HTTPS URL of a Web App to be opened
+	URL string `json:"url"`
+}
+
+// SetMyName changes the bot's name for the given language. If languageCode,
+// a two-letter ISO 639-1 code, is empty, the name will be shown to all users
+// for whose language there is no dedicated name. Pass an empty name to remove
+// the dedicated name for the given language.
+func (b *Bot) SetMyName(name, languageCode string) error {
+	return b.setMyText("setMyName", "name", name, languageCode)
+}
+
+// GetMyName retrieves the current bot name for the given language.
+func (b *Bot) GetMyName(languageCode string) (string, error) {
+	return b.getMyText("getMyName", "name", languageCode)
+}
+
+// SetMyDescription changes the bot's description, which is shown in the chat
+// with the bot if the chat is empty, for the given language. Pass an empty
+// description to remove the dedicated description for the given language.
+func (b *Bot) SetMyDescription(description, languageCode string) error {
+	return b.setMyText("setMyDescription", "description", description, languageCode)
+}
+
+// GetMyDescription retrieves the current bot description for the given
+// language.
+func (b *Bot) GetMyDescription(languageCode string) (string, error) {
+	return b.getMyText("getMyDescription", "description", languageCode)
+}
+
+// SetMyShortDescription changes the bot's short description, which is shown
+// on the bot's profile page and is sent together with the link when users
+// share the bot, for the given language. Pass an empty description to remove
+// the dedicated short description for the given language.
+func (b *Bot) SetMyShortDescription(description, languageCode string) error {
+	return b.setMyText("setMyShortDescription", "short_description", description, languageCode)
+}
+
+// GetMyShortDescription retrieves the current bot short description for the
+// given language.
+func (b *Bot) GetMyShortDescription(languageCode string) (string, error) {
+	return b.getMyText("getMyShortDescription", "short_description", languageCode)
+}
+
+// SetChatMenuButton changes the bot's menu button in a private chat. If chatID
+// is 0, the default menu button is changed.
+func (b *Bot) SetChatMenuButton(chatID int64, button MenuButton) error {
+	const method = "setChatMenuButton"
+	params := url.Values{}
+	if chatID != 0 {
+		params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	}
+
+	btn, err := json.Marshal(button)
+	if err != nil {
+		return err
+	}
+	params.Set("menu_button", string(btn))
+
+	var r response
+	err = b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// GetChatMenuButton retrieves the current value of the bot's menu button in a
+// private chat. If chatID is 0, the default menu button is returned.
+func (b *Bot) GetChatMenuButton(chatID int64) (MenuButton, error) {
+	const method = "getChatMenuButton"
+	params := url.Values{}
+	if chatID != 0 {
+		params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	}
+
+	var r struct {
+		response
+		Button MenuButton `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return MenuButton{}, err
+	}
+
+	if !r.OK {
+		return MenuButton{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Button, nil
+}
+
+// SetMyDefaultAdministratorRights changes the default administrator rights
+// requested by the bot when it's added as an administrator to groups or
+// channels. If forChannels is true, the rights for channels are changed.
+// Otherwise, the rights for groups and supergroups are changed.
+func (b *Bot) SetMyDefaultAdministratorRights(rights ChatAdministratorRights, forChannels bool) error {
+	const method = "setMyDefaultAdministratorRights"
+	params := url.Values{}
+
+	rgts, err := json.Marshal(rights)
+	if err != nil {
+		return err
+	}
+	params.Set("rights", string(rgts))
+
+	if forChannels {
+		params.Set("for_channels", "true")
+	}
+
+	var r response
+	err = b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// GetMyDefaultAdministratorRights retrieves the current default administrator
+// rights of the bot for groups and supergroups, or for channels if
+// forChannels is true.
+func (b *Bot) GetMyDefaultAdministratorRights(forChannels bool) (ChatAdministratorRights, error) {
+	const method = "getMyDefaultAdministratorRights"
+	params := url.Values{}
+	if forChannels {
+		params.Set("for_channels", "true")
+	}
+
+	var r struct {
+		response
+		Rights ChatAdministratorRights `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return ChatAdministratorRights{}, err
+	}
+
+	if !r.OK {
+		return ChatAdministratorRights{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Rights, nil
+}
+
+// setMyText calls one of the methods which set a localized text of the bot's
+// profile, such as its name or description.
+func (b *Bot) setMyText(method, field, text, languageCode string) error {
+	params := url.Values{}
+	if text != "" {
+		params.Set(field, text)
+	}
+
+	if languageCode != "" {
+		params.Set("language_code", languageCode)
+	}
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// getMyText calls one of the methods which get a localized text of the bot's
+// profile. The result is an object with the text in the given field.
+func (b *Bot) getMyText(method, field, languageCode string) (string, error) {
+	params := url.Values{}
+	if languageCode != "" {
+		params.Set("language_code", languageCode)
+	}
+
+	var r struct {
+		response
+		Result map[string]string `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return "", err
+	}
+
+	if !r.OK {
+		return "", fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Result[field], nil
+}
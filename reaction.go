@@ -0,0 +1,284 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ReactionType describes the type of a reaction. Its dynamic type is one of
+// ReactionTypeEmoji, ReactionTypeCustomEmoji or ReactionTypePaid, or
+// ReactionTypeUnknown for types introduced to Telegram later. Reaction types
+// are comparable, so that reactions can be compared with ==.
+type ReactionType interface {
+	reactionType()
+}
+
+// ReactionTypeEmoji is a reaction based on an emoji.
+type ReactionTypeEmoji struct {
+	// Reaction emoji, such as “👍”, “❤” or “🔥”. Only the emoji which are
+	// available as reactions in Telegram clients can be used
+	Emoji string `json:"emoji"`
+}
+
+// ReactionTypeCustomEmoji is a reaction based on a custom emoji.
+type ReactionTypeCustomEmoji struct {
+	// Custom emoji identifier
+	CustomEmojiID string `json:"custom_emoji_id"`
+}
+
+// ReactionTypePaid is a paid reaction, which is sent with Telegram Stars.
+type ReactionTypePaid struct{}
+
+// ReactionTypeUnknown is a reaction of a type which is not known to this
+// package. It is only received, and can't be set by the bot.
+type ReactionTypeUnknown struct {
+	// Type of the reaction
+	Type string `json:"type"`
+}
+
+func (ReactionTypeEmoji) reactionType()       {}
+func (ReactionTypeCustomEmoji) reactionType() {}
+func (ReactionTypePaid) reactionType()        {}
+func (ReactionTypeUnknown) reactionType()     {}
+
+// MarshalJSON implements json.Marshaler.
+func (t ReactionTypeEmoji) MarshalJSON() ([]byte, error) {
+	type reaction ReactionTypeEmoji
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		reaction
+	}{"emoji", reaction(t)})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t ReactionTypeCustomEmoji) MarshalJSON() ([]byte, error) {
+	type reaction ReactionTypeCustomEmoji
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		reaction
+	}{"custom_emoji", reaction(t)})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t ReactionTypePaid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+	}{"paid"})
+}
+
+// unmarshalReactionType decodes a reaction type into its concrete type.
+func unmarshalReactionType(data []byte) (ReactionType, error) {
+	var s struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	switch s.Type {
+	case "emoji":
+		var v ReactionTypeEmoji
+		err := json.Unmarshal(data, &v)
+		return v, err
+	case "custom_emoji":
+		var v ReactionTypeCustomEmoji
+		err := json.Unmarshal(data, &v)
+		return v, err
+	case "paid":
+		return ReactionTypePaid{}, nil
+	default:
+		return ReactionTypeUnknown{Type: s.Type}, nil
+	}
+}
+
+// unmarshalReactionTypes decodes a list of reaction types into their concrete
+// types.
+func unmarshalReactionTypes(data []json.RawMessage) ([]ReactionType, error) {
+	var types []ReactionType
+	for _, raw := range data {
+		t, err := unmarshalReactionType(raw)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+// ReactionCount represents a reaction added to a message along with the
+// number of times it was added.
+type ReactionCount struct {
+	// Type of the reaction
+	Type ReactionType `json:"-"`
+
+	// Number of times the reaction was added
+	TotalCount int `json:"total_count"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The reaction type is decoded
+// into its concrete type.
+func (c *ReactionCount) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Type       json.RawMessage `json:"type"`
+		TotalCount int             `json:"total_count"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	t, err := unmarshalReactionType(v.Type)
+	if err != nil {
+		return err
+	}
+	*c = ReactionCount{Type: t, TotalCount: v.TotalCount}
+	return nil
+}
+
+// MessageReactionUpdated represents a change of a reaction on a message
+// performed by a user. The bot must be an administrator in the chat to
+// receive these updates.
+type MessageReactionUpdated struct {
+	// The chat containing the message the user reacted to
+	Chat Chat `json:"chat"`
+
+	// Unique identifier of the message inside the chat
+	MessageID int64 `json:"message_id"`
+
+	// Optional. The user that changed the reaction, if the user isn't
+	// anonymous
+	User *User `json:"user,omitempty"`
+
+	// Optional. The chat on behalf of which the reaction was changed, if the
+	// user is anonymous
+	ActorChat *Chat `json:"actor_chat,omitempty"`
+
+	// Date of the change in Unix time
+	Unixtime int64 `json:"date"`
+
+	// Previous list of reaction types that were set by the user
+	OldReaction []ReactionType `json:"-"`
+
+	// New list of reaction types that have been set by the user
+	NewReaction []ReactionType `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Old and new reactions are
+// decoded into their concrete types.
+func (u *MessageReactionUpdated) UnmarshalJSON(data []byte) error {
+	type update MessageReactionUpdated
+	var v struct {
+		update
+		Old []json.RawMessage `json:"old_reaction"`
+		New []json.RawMessage `json:"new_reaction"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*u = MessageReactionUpdated(v.update)
+
+	var err error
+	u.OldReaction, err = unmarshalReactionTypes(v.Old)
+	if err != nil {
+		return err
+	}
+	u.NewReaction, err = unmarshalReactionTypes(v.New)
+	return err
+}
+
+// Time returns the moment of the change in UTC time.
+func (u MessageReactionUpdated) Time() time.Time {
+	return time.Unix(u.Unixtime, 0).UTC()
+}
+
+// Added returns the reactions which are in the new reactions of the user but
+// not in the old ones.
+func (u MessageReactionUpdated) Added() []ReactionType {
+	return reactionDiff(u.NewReaction, u.OldReaction)
+}
+
+// Removed returns the reactions which are in the old reactions of the user
+// but not in the new ones.
+func (u MessageReactionUpdated) Removed() []ReactionType {
+	return reactionDiff(u.OldReaction, u.NewReaction)
+}
+
+// reactionDiff returns the reactions in a which are not in b.
+func reactionDiff(a, b []ReactionType) []ReactionType {
+	var diff []ReactionType
+	for _, x := range a {
+		found := false
+		for _, y := range b {
+			if x == y {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff = append(diff, x)
+		}
+	}
+	return diff
+}
+
+// MessageReactionCountUpdated represents changes of anonymous reactions on a
+// message. The bot must be an administrator in the chat to receive these
+// updates. The updates are grouped and can be sent with delay up to a few
+// minutes.
+type MessageReactionCountUpdated struct {
+	// The chat containing the message
+	Chat Chat `json:"chat"`
+
+	// Unique message identifier inside the chat
+	MessageID int64 `json:"message_id"`
+
+	// Date of the change in Unix time
+	Unixtime int64 `json:"date"`
+
+	// List of reactions that are present on the message
+	Reactions []ReactionCount `json:"reactions"`
+}
+
+// Time returns the moment of the change in UTC time.
+func (u MessageReactionCountUpdated) Time() time.Time {
+	return time.Unix(u.Unixtime, 0).UTC()
+}
+
+// SetMessageReaction changes the reactions of the bot on a message. Bots
+// can't use paid reactions, and unless the bot is a Premium user, it can set
+// up to one reaction per message. Passing no reactions removes the reactions
+// of the bot. If isBig is true, the reaction is shown with a big animation.
+func (b *Bot) SetMessageReaction(chatID, messageID int64, reactions []ReactionType, isBig bool) error {
+	const method = "setMessageReaction"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("message_id", strconv.FormatInt(messageID, 10))
+
+	if reactions == nil {
+		reactions = []ReactionType{}
+	}
+	rs, err := json.Marshal(reactions)
+	if err != nil {
+		return err
+	}
+	params.Set("reaction", string(rs))
+
+	if isBig {
+		params.Set("is_big", "true")
+	}
+
+	var r response
+	err = b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,853 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// HTML returns the message text, or caption if the message has no text,
+// formatted with its entities for ModeHTML. See RenderHTML.
+func (m Message) HTML() string {
+	text := m.Text
+	if text == "" {
+		text = m.Caption
+	}
+	return RenderHTML(text, m.AllEntities())
+}
+
+// MarkdownV2 returns the message text, or caption if the message has no text,
+// formatted with its entities for ModeMarkdownV2. See RenderMarkdownV2.
+func (m Message) MarkdownV2() string {
+	text := m.Text
+	if text == "" {
+		text = m.Caption
+	}
+	return RenderMarkdownV2(text, m.AllEntities())
+}
+
+// RenderHTML formats text with the given entities for ModeHTML. Nested
+// entities are supported, and overlapping entities are split so that the tags
+// are properly nested. Entities which are detected by Telegram automatically,
+// such as mentions and URLs, are rendered as plain text.
+//
+// ParseHTML reverses it: parsing the result gives back text and the entities
+// as normalized by NormalizeEntities, except the automatically detected ones.
+func RenderHTML(text string, entities []MessageEntity) string {
+	var w htmlWriter
+	renderEntities(text, entities, &w)
+	return w.buf.String()
+}
+
+// RenderMarkdownV2 formats text with the given entities for ModeMarkdownV2.
+// Nested entities are supported, and overlapping entities are split so that
+// they are properly nested. Entities which are detected by Telegram
+// automatically, such as mentions and URLs, are rendered as plain text.
+//
+// Blockquotes span whole lines in MarkdownV2, so a line break is inserted
+// before a blockquote which starts in the middle of a line, and after one
+// which ends in the middle of a line. Apart from these, ParseMarkdownV2
+// reverses it: parsing the result gives back text and the entities as
+// normalized by NormalizeEntities, except the automatically detected ones.
+func RenderMarkdownV2(text string, entities []MessageEntity) string {
+	var w markdownV2Writer
+	renderEntities(text, entities, &w)
+	return w.buf.String()
+}
+
+// entityRanks orders the types of entities which share the same span, from
+// the outermost to the innermost.
+var entityRanks = map[string]int{
+	EntityBlockquote:    1,
+	EntityTextLink:      2,
+	EntityTextMention:   3,
+	EntityBold:          4,
+	EntityItalic:        5,
+	EntityUnderline:     6,
+	EntityStrikethrough: 7,
+	EntitySpoiler:       8,
+	EntityPre:           9,
+	EntityCode:          10,
+	EntityCustomEmoji:   11,
+}
+
+// NormalizeEntities returns the entities of text in a canonical form, which
+// formats text the same way. Entities are clipped to the text and empty ones
+// are dropped. Overlapping or adjacent entities of the same kind are merged
+// into one, except custom emoji, which each cover a single emoji. Telegram
+// doesn't format the text inside code and pre entities, so the entities
+// nested in them are dropped and the ones overlapping them are clipped, and
+// blockquotes don't include the line breaks they end with. The result is
+// ordered by offset, then the longer entities first, and then the outer ones
+// first for entities with the same span.
+func NormalizeEntities(text string, entities []MessageEntity) []MessageEntity {
+	n := utf16Len(text)
+	var norm []MessageEntity
+	for _, e := range entities {
+		start, end := e.Offset, e.Offset+e.Length
+		if start < 0 {
+			start = 0
+		}
+		if end > n {
+			end = n
+		}
+		for e.Type == EntityBlockquote && end > start && utf16Slice(text, end-1, 1) == "\n" {
+			end--
+		}
+		if start >= end {
+			continue
+		}
+		e.Offset, e.Length = start, end-start
+		norm = append(norm, e)
+	}
+	sortEntities(norm)
+
+	merged := norm[:0]
+	for _, e := range norm {
+		dup := false
+		for i := range merged {
+			m := &merged[i]
+			if e.Type != EntityCustomEmoji && m.Offset+m.Length >= e.Offset && sameEntity(*m, e) {
+				if end := e.Offset + e.Length; end > m.Offset+m.Length {
+					m.Length = end - m.Offset
+				}
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			merged = append(merged, e)
+		}
+	}
+
+	// code holds the spans of the code and pre entities kept so far. As the
+	// entities are sorted, the ones which contain a code span come before it.
+	var code [][2]int
+	var clipped []MessageEntity
+	for _, e := range merged {
+		spans := [][2]int{{e.Offset, e.Offset + e.Length}}
+		for _, c := range code {
+			spans = subtractSpan(spans, c)
+		}
+		if e.Type == EntityCustomEmoji && (len(spans) != 1 || spans[0][1]-spans[0][0] != e.Length) {
+			continue
+		}
+		for _, sp := range spans {
+			e.Offset, e.Length = sp[0], sp[1]-sp[0]
+			clipped = append(clipped, e)
+			if e.Type == EntityCode || e.Type == EntityPre {
+				code = append(code, sp)
+			}
+		}
+	}
+	if len(clipped) == 0 {
+		return nil
+	}
+	sortEntities(clipped)
+	return clipped
+}
+
+// subtractSpan returns the parts of spans which are not covered by span c.
+func subtractSpan(spans [][2]int, c [2]int) [][2]int {
+	var rest [][2]int
+	for _, sp := range spans {
+		if c[1] <= sp[0] || sp[1] <= c[0] {
+			rest = append(rest, sp)
+			continue
+		}
+		if sp[0] < c[0] {
+			rest = append(rest, [2]int{sp[0], c[0]})
+		}
+		if c[1] < sp[1] {
+			rest = append(rest, [2]int{c[1], sp[1]})
+		}
+	}
+	return rest
+}
+
+// sortEntities sorts entities by offset, then the longer ones first, and then
+// by entityRanks.
+func sortEntities(entities []MessageEntity) {
+	rank := func(e MessageEntity) int {
+		if r, ok := entityRanks[e.Type]; ok {
+			return r
+		}
+		return len(entityRanks) + 1
+	}
+	sort.SliceStable(entities, func(i, j int) bool {
+		a, b := entities[i], entities[j]
+		if a.Offset != b.Offset {
+			return a.Offset < b.Offset
+		}
+		if a.Length != b.Length {
+			return a.Length > b.Length
+		}
+		return rank(a) < rank(b)
+	})
+}
+
+// sameEntity reports whether a and b format text the same way.
+func sameEntity(a, b MessageEntity) bool {
+	if a.Type != b.Type || a.URL != b.URL || a.Language != b.Language || a.CustomEmojiID != b.CustomEmojiID {
+		return false
+	}
+	if a.User == nil || b.User == nil {
+		return a.User == b.User
+	}
+	return a.User.ID == b.User.ID
+}
+
+// entityWriter writes a text formatted with entities, see renderEntities.
+type entityWriter interface {
+	open(e MessageEntity)
+	close(e MessageEntity)
+	text(s string)
+}
+
+// renderEntities walks through text and calls w for each piece of text and
+// each entity boundary, so that entities are always properly nested.
+// Entities which overlap partially are closed and reopened as needed.
+func renderEntities(text string, entities []MessageEntity, w entityWriter) {
+	// byte index of each UTF-16 position which starts a rune.
+	index := map[int]int{}
+	n := 0
+	for i, r := range text {
+		index[n] = i
+		n += utf16RuneLen(r)
+	}
+	index[n] = len(text)
+
+	// byteAt returns the byte index of UTF-16 position pos, rounding up to
+	// the next rune boundary.
+	byteAt := func(pos int) int {
+		for ; pos < n; pos++ {
+			if i, ok := index[pos]; ok {
+				return i
+			}
+		}
+		return len(text)
+	}
+
+	type span struct {
+		e          MessageEntity
+		start, end int
+	}
+	var spans []span
+	bounds := []int{0, n}
+	for _, e := range NormalizeEntities(text, entities) {
+		spans = append(spans, span{e: e, start: e.Offset, end: e.Offset + e.Length})
+		bounds = append(bounds, e.Offset, e.Offset+e.Length)
+	}
+	sort.Ints(bounds)
+
+	var stack []span
+	next := 0
+	pos := 0
+	for _, b := range bounds {
+		if b < pos {
+			continue
+		}
+		if b > pos {
+			w.text(text[byteAt(pos):byteAt(b)])
+			pos = b
+		}
+
+		// close the entities ending here, along with the ones opened after
+		// them, which are reopened afterwards.
+		lowest := len(stack)
+		for i, s := range stack {
+			if s.end == b {
+				lowest = i
+				break
+			}
+		}
+		var reopen []span
+		for i := len(stack) - 1; i >= lowest; i-- {
+			w.close(stack[i].e)
+			if stack[i].end != b {
+				reopen = append([]span{stack[i]}, reopen...)
+			}
+		}
+		stack = stack[:lowest]
+		for _, s := range reopen {
+			w.open(s.e)
+			stack = append(stack, s)
+		}
+
+		for next < len(spans) && spans[next].start == b {
+			w.open(spans[next].e)
+			stack = append(stack, spans[next])
+			next++
+		}
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		w.close(stack[i].e)
+	}
+}
+
+type htmlWriter struct {
+	buf strings.Builder
+}
+
+func (w *htmlWriter) open(e MessageEntity) {
+	switch e.Type {
+	case EntityBold:
+		w.buf.WriteString("<b>")
+	case EntityItalic:
+		w.buf.WriteString("<i>")
+	case EntityUnderline:
+		w.buf.WriteString("<u>")
+	case EntityStrikethrough:
+		w.buf.WriteString("<s>")
+	case EntitySpoiler:
+		w.buf.WriteString("<tg-spoiler>")
+	case EntityCode:
+		w.buf.WriteString("<code>")
+	case EntityPre:
+		if e.Language != "" {
+			w.buf.WriteString(`<pre><code class="language-` + EscapeHTMLURL(e.Language) + `">`)
+		} else {
+			w.buf.WriteString("<pre>")
+		}
+	case EntityBlockquote:
+		w.buf.WriteString("<blockquote>")
+	case EntityTextLink:
+		w.buf.WriteString(`<a href="` + EscapeHTMLURL(e.URL) + `">`)
+	case EntityTextMention:
+		w.buf.WriteString(`<a href="` + mentionURL(e) + `">`)
+	case EntityCustomEmoji:
+		w.buf.WriteString(`<tg-emoji emoji-id="` + EscapeHTMLURL(e.CustomEmojiID) + `">`)
+	}
+}
+
+func (w *htmlWriter) close(e MessageEntity) {
+	switch e.Type {
+	case EntityBold:
+		w.buf.WriteString("</b>")
+	case EntityItalic:
+		w.buf.WriteString("</i>")
+	case EntityUnderline:
+		w.buf.WriteString("</u>")
+	case EntityStrikethrough:
+		w.buf.WriteString("</s>")
+	case EntitySpoiler:
+		w.buf.WriteString("</tg-spoiler>")
+	case EntityCode:
+		w.buf.WriteString("</code>")
+	case EntityPre:
+		if e.Language != "" {
+			w.buf.WriteString("</code></pre>")
+		} else {
+			w.buf.WriteString("</pre>")
+		}
+	case EntityBlockquote:
+		w.buf.WriteString("</blockquote>")
+	case EntityTextLink, EntityTextMention:
+		w.buf.WriteString("</a>")
+	case EntityCustomEmoji:
+		w.buf.WriteString("</tg-emoji>")
+	}
+}
+
+func (w *htmlWriter) text(s string) {
+	w.buf.WriteString(EscapeHTML(s))
+}
+
+type markdownV2Writer struct {
+	buf   strings.Builder
+	code  int // number of open code and pre entities
+	quote int // number of open blockquote entities
+	last  byte
+
+	// endQuote is set when a blockquote is closed, so that a line break is
+	// inserted unless the text goes on with one. Blockquotes span whole lines
+	// in MarkdownV2.
+	endQuote bool
+}
+
+func (w *markdownV2Writer) open(e MessageEntity) {
+	switch e.Type {
+	case EntityBold:
+		w.marker("*")
+	case EntityItalic:
+		w.marker("_")
+	case EntityUnderline:
+		w.marker("__")
+	case EntityStrikethrough:
+		w.marker("~")
+	case EntitySpoiler:
+		w.marker("||")
+	case EntityCode:
+		w.marker("`")
+		w.code++
+	case EntityPre:
+		w.marker("```" + e.Language + "\n")
+		w.code++
+	case EntityBlockquote:
+		if w.quote == 0 {
+			if w.buf.Len() > 0 && w.last != '\n' {
+				w.write("\n")
+			}
+			w.marker(">")
+		}
+		w.quote++
+	case EntityTextLink, EntityTextMention:
+		w.marker("[")
+	case EntityCustomEmoji:
+		w.marker("![")
+	}
+}
+
+func (w *markdownV2Writer) close(e MessageEntity) {
+	switch e.Type {
+	case EntityBold:
+		w.marker("*")
+	case EntityItalic:
+		w.marker("_")
+	case EntityUnderline:
+		w.marker("__")
+	case EntityStrikethrough:
+		w.marker("~")
+	case EntitySpoiler:
+		w.marker("||")
+	case EntityCode:
+		w.marker("`")
+		w.code--
+	case EntityPre:
+		w.marker("```")
+		w.code--
+	case EntityBlockquote:
+		w.quote--
+		w.endQuote = w.quote == 0
+	case EntityTextLink:
+		w.marker("](" + EscapeMarkdownV2URL(e.URL) + ")")
+	case EntityTextMention:
+		w.marker("](" + EscapeMarkdownV2URL(mentionURL(e)) + ")")
+	case EntityCustomEmoji:
+		w.marker("](tg://emoji?id=" + EscapeMarkdownV2URL(e.CustomEmojiID) + ")")
+	}
+}
+
+// marker writes a formatting marker. An ignored carriage return separates
+// underscores of adjacent italic and underline markers, which would be
+// ambiguous otherwise.
+func (w *markdownV2Writer) marker(m string) {
+	if w.last == '_' && m[0] == '_' {
+		w.buf.WriteByte('\r')
+	}
+	w.write(m)
+}
+
+func (w *markdownV2Writer) text(s string) {
+	if w.code > 0 {
+		s = EscapeMarkdownV2Code(s)
+	} else {
+		s = EscapeMarkdownV2(s)
+	}
+	if w.quote > 0 {
+		s = strings.Replace(s, "\n", "\n>", -1)
+	}
+	w.write(s)
+}
+
+func (w *markdownV2Writer) write(s string) {
+	if s == "" {
+		return
+	}
+	if w.endQuote {
+		w.endQuote = false
+		if s[0] != '\n' {
+			w.buf.WriteByte('\n')
+		}
+	}
+	w.buf.WriteString(s)
+	w.last = s[len(s)-1]
+}
+
+// mentionURL returns the URL which mentions the user of a text_mention
+// entity.
+func mentionURL(e MessageEntity) string {
+	var id int64
+	if e.User != nil {
+		id = e.User.ID
+	}
+	return "tg://user?id=" + strconv.FormatInt(id, 10)
+}
+
+// parseMentionURL parses the user ID of a URL returned by mentionURL. It
+// reports whether u is such a URL.
+func parseMentionURL(u string) (int64, bool) {
+	const prefix = "tg://user?id="
+	if !strings.HasPrefix(u, prefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(u[len(prefix):], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// entityBuilder accumulates a plain text and its entities while parsing a
+// formatted text.
+type entityBuilder struct {
+	text     strings.Builder
+	n        int // length of text in UTF-16 code units
+	entities []MessageEntity
+}
+
+func (b *entityBuilder) write(s string) {
+	b.text.WriteString(s)
+	b.n += utf16Len(s)
+}
+
+// add adds e, which starts at start and ends at the current position, unless
+// it is empty.
+func (b *entityBuilder) add(e MessageEntity, start int) {
+	if b.n <= start {
+		return
+	}
+	e.Offset = start
+	e.Length = b.n - start
+	b.entities = append(b.entities, e)
+}
+
+// result returns the text and its entities, normalized by
+// NormalizeEntities, so that the fragments of entities which were split to be
+// properly nested are merged back.
+func (b *entityBuilder) result() (string, []MessageEntity) {
+	text := b.text.String()
+	return text, NormalizeEntities(text, b.entities)
+}
+
+// ParseHTML parses a text formatted for ModeHTML and returns the plain text
+// and its entities, normalized by NormalizeEntities. See RenderHTML.
+func ParseHTML(s string) (string, []MessageEntity, error) {
+	type tag struct {
+		name   string
+		entity MessageEntity
+		start  int
+	}
+
+	var b entityBuilder
+	var stack []tag
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			b.write(html.UnescapeString(s))
+			break
+		}
+		b.write(html.UnescapeString(s[:i]))
+		s = s[i:]
+
+		j := strings.IndexByte(s, '>')
+		if j < 0 {
+			return "", nil, errors.New("html: unclosed tag")
+		}
+		raw := s[1:j]
+		s = s[j+1:]
+
+		if strings.HasPrefix(raw, "/") {
+			name := strings.ToLower(strings.TrimSpace(raw[1:]))
+			if len(stack) == 0 || stack[len(stack)-1].name != name {
+				return "", nil, fmt.Errorf("html: unexpected end tag </%v>", name)
+			}
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			// <pre><code class="language-x"> is a single pre entity with
+			// a language.
+			if t.name == "code" && t.entity.Language != "" &&
+				len(stack) > 0 && stack[len(stack)-1].name == "pre" {
+				stack[len(stack)-1].entity.Language = t.entity.Language
+				continue
+			}
+			if t.entity.Type != "" {
+				b.add(t.entity, t.start)
+			}
+			continue
+		}
+
+		name, attrs := parseHTMLTag(raw)
+		var e MessageEntity
+		switch name {
+		case "b", "strong":
+			e.Type = EntityBold
+		case "i", "em":
+			e.Type = EntityItalic
+		case "u", "ins":
+			e.Type = EntityUnderline
+		case "s", "strike", "del":
+			e.Type = EntityStrikethrough
+		case "tg-spoiler":
+			e.Type = EntitySpoiler
+		case "span":
+			if attrs["class"] != "tg-spoiler" {
+				return "", nil, errors.New(`html: unsupported span, only <span class="tg-spoiler"> is allowed`)
+			}
+			e.Type = EntitySpoiler
+		case "code":
+			e.Type = EntityCode
+			if lang := strings.TrimPrefix(attrs["class"], "language-"); lang != attrs["class"] &&
+				len(stack) > 0 && stack[len(stack)-1].name == "pre" {
+				e.Language = lang
+			}
+		case "pre":
+			e.Type = EntityPre
+		case "blockquote":
+			e.Type = EntityBlockquote
+		case "a":
+			href := attrs["href"]
+			if id, ok := parseMentionURL(href); ok {
+				e.Type = EntityTextMention
+				e.User = &User{ID: id}
+			} else {
+				e.Type = EntityTextLink
+				e.URL = href
+			}
+		case "tg-emoji":
+			e.Type = EntityCustomEmoji
+			e.CustomEmojiID = attrs["emoji-id"]
+		default:
+			return "", nil, fmt.Errorf("html: unsupported tag <%v>", name)
+		}
+		stack = append(stack, tag{name: name, entity: e, start: b.n})
+	}
+
+	if len(stack) > 0 {
+		return "", nil, fmt.Errorf("html: unclosed tag <%v>", stack[len(stack)-1].name)
+	}
+
+	text, entities := b.result()
+	return text, entities, nil
+}
+
+// parseHTMLTag parses the name and attributes of a start tag, given without
+// the angle brackets.
+func parseHTMLTag(raw string) (string, map[string]string) {
+	raw = strings.TrimSuffix(strings.TrimSpace(raw), "/")
+	i := strings.IndexAny(raw, " \t\r\n")
+	if i < 0 {
+		return strings.ToLower(raw), nil
+	}
+	name := strings.ToLower(raw[:i])
+	rest := raw[i:]
+
+	attrs := map[string]string{}
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n")
+		if rest == "" {
+			break
+		}
+		j := strings.IndexAny(rest, "= \t\r\n")
+		if j < 0 {
+			attrs[strings.ToLower(rest)] = ""
+			break
+		}
+		key := strings.ToLower(rest[:j])
+		rest = strings.TrimLeft(rest[j:], " \t\r\n")
+		if !strings.HasPrefix(rest, "=") {
+			attrs[key] = ""
+			continue
+		}
+		rest = strings.TrimLeft(rest[1:], " \t\r\n")
+
+		var value string
+		if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+			k := strings.IndexByte(rest[1:], rest[0])
+			if k < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:k+1], rest[k+2:]
+			}
+		} else {
+			k := strings.IndexAny(rest, " \t\r\n")
+			if k < 0 {
+				k = len(rest)
+			}
+			value, rest = rest[:k], rest[k:]
+		}
+		attrs[key] = html.UnescapeString(value)
+	}
+	return name, attrs
+}
+
+// ParseMarkdownV2 parses a text formatted for ModeMarkdownV2 and returns the
+// plain text and its entities, normalized by NormalizeEntities. See
+// RenderMarkdownV2.
+func ParseMarkdownV2(s string) (string, []MessageEntity, error) {
+	var b entityBuilder
+	open := map[string]int{} // marker -> start of the open entity
+	var links []int          // starts of the open links; negative for custom emoji
+	quote := -1              // start of the open blockquote
+
+	styles := []struct {
+		marker string
+		typ    string
+	}{
+		{"||", EntitySpoiler},
+		{"__", EntityUnderline},
+		{"*", EntityBold},
+		{"_", EntityItalic},
+		{"~", EntityStrikethrough},
+	}
+
+	lineStart := true
+	for i := 0; i < len(s); {
+		if lineStart && s[i] == '>' {
+			if quote < 0 {
+				quote = b.n
+			}
+			i++
+			lineStart = false
+			continue
+		}
+		lineStart = false
+
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			_, size := utf8.DecodeRuneInString(s[i+1:])
+			b.write(s[i+1 : i+1+size])
+			i += 1 + size
+			continue
+		case c == '\r':
+			i++
+			continue
+		case c == '\n':
+			if quote >= 0 && (i+1 >= len(s) || s[i+1] != '>') {
+				b.add(MessageEntity{Type: EntityBlockquote}, quote)
+				quote = -1
+			}
+			b.write("\n")
+			lineStart = true
+			i++
+			continue
+		case strings.HasPrefix(s[i:], "```"):
+			code, lang, n, err := parseMarkdownV2Pre(s[i:])
+			if err != nil {
+				return "", nil, err
+			}
+			start := b.n
+			b.write(code)
+			b.add(MessageEntity{Type: EntityPre, Language: lang}, start)
+			i += n
+			continue
+		case c == '`':
+			code, n, err := parseMarkdownV2Code(s[i+1:], "`")
+			if err != nil {
+				return "", nil, err
+			}
+			start := b.n
+			b.write(code)
+			b.add(MessageEntity{Type: EntityCode}, start)
+			i += 1 + n
+			continue
+		case c == '[':
+			links = append(links, b.n)
+			i++
+			continue
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			links = append(links, -b.n-1)
+			i += 2
+			continue
+		case c == ']' && len(links) > 0:
+			if i+1 >= len(s) || s[i+1] != '(' {
+				return "", nil, errors.New("markdown: expected link URL after ]")
+			}
+			u, n, err := parseMarkdownV2Code(s[i+2:], ")")
+			if err != nil {
+				return "", nil, err
+			}
+			start := links[len(links)-1]
+			links = links[:len(links)-1]
+
+			var e MessageEntity
+			if start < 0 {
+				start = -start - 1
+				e.Type = EntityCustomEmoji
+				e.CustomEmojiID = strings.TrimPrefix(u, "tg://emoji?id=")
+			} else if id, ok := parseMentionURL(u); ok {
+				e.Type = EntityTextMention
+				e.User = &User{ID: id}
+			} else {
+				e.Type = EntityTextLink
+				e.URL = u
+			}
+			b.add(e, start)
+			i += 2 + n
+			continue
+		}
+
+		matched := false
+		for _, st := range styles {
+			if !strings.HasPrefix(s[i:], st.marker) {
+				continue
+			}
+			if start, ok := open[st.marker]; ok {
+				b.add(MessageEntity{Type: st.typ}, start)
+				delete(open, st.marker)
+			} else {
+				open[st.marker] = b.n
+			}
+			i += len(st.marker)
+			matched = true
+			break
+		}
+		if matched {
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		b.write(s[i : i+size])
+		i += size
+	}
+
+	if quote >= 0 {
+		b.add(MessageEntity{Type: EntityBlockquote}, quote)
+	}
+	if len(open) > 0 || len(links) > 0 {
+		return "", nil, errors.New("markdown: unclosed entity")
+	}
+
+	text, entities := b.result()
+	return text, entities, nil
+}
+
+// parseMarkdownV2Code parses s up to the unescaped terminator term, and
+// returns the unescaped text and the number of bytes consumed, including the
+// terminator.
+func parseMarkdownV2Code(s, term string) (string, int, error) {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			buf.WriteByte(s[i+1])
+			i++
+		case strings.HasPrefix(s[i:], term):
+			return buf.String(), i + len(term), nil
+		default:
+			buf.WriteByte(s[i])
+		}
+	}
+	return "", 0, fmt.Errorf("markdown: missing closing %v", term)
+}
+
+// parseMarkdownV2Pre parses a pre block starting with ``` and returns the
+// code, its language and the number of bytes consumed.
+func parseMarkdownV2Pre(s string) (code, lang string, n int, err error) {
+	rest := s[3:]
+	if i := strings.IndexByte(rest, '\n'); i >= 0 && !strings.ContainsAny(rest[:i], "`\\ ") {
+		lang = rest[:i]
+		rest = rest[i+1:]
+	}
+	code, m, err := parseMarkdownV2Code(rest, "```")
+	if err != nil {
+		return "", "", 0, err
+	}
+	return code, lang, len(s) - len(rest) + m, nil
+}
@@ -0,0 +1,378 @@
+package telegram
+
+import (
+	"reflect"
+	"testing"
+)
+
+var renderTests = []struct {
+	name     string
+	text     string
+	entities []MessageEntity
+
+	// text and entities parsed back from MarkdownV2, if they differ from
+	// the normalized input due to the line breaks inserted around
+	// blockquotes.
+	markdownV2Text     string
+	markdownV2Entities []MessageEntity
+}{
+	{
+		name: "plain",
+		text: "hello world",
+	},
+	{
+		name: "nested",
+		text: "bold italic bold",
+		entities: []MessageEntity{
+			{Type: EntityBold, Offset: 0, Length: 16},
+			{Type: EntityItalic, Offset: 5, Length: 6},
+		},
+	},
+	{
+		name: "overlapping",
+		text: "abcdef",
+		entities: []MessageEntity{
+			{Type: EntityBold, Offset: 0, Length: 4},
+			{Type: EntityItalic, Offset: 2, Length: 4},
+		},
+	},
+	{
+		name: "overlapping three",
+		text: "abcdefgh",
+		entities: []MessageEntity{
+			{Type: EntityBold, Offset: 0, Length: 4},
+			{Type: EntityItalic, Offset: 2, Length: 4},
+			{Type: EntityStrikethrough, Offset: 3, Length: 5},
+		},
+	},
+	{
+		name: "same span",
+		text: "abc",
+		entities: []MessageEntity{
+			{Type: EntitySpoiler, Offset: 0, Length: 3},
+			{Type: EntityItalic, Offset: 0, Length: 3},
+			{Type: EntityBold, Offset: 0, Length: 3},
+			{Type: EntityUnderline, Offset: 0, Length: 3},
+		},
+	},
+	{
+		name: "adjacent italic and underline",
+		text: "abcd",
+		entities: []MessageEntity{
+			{Type: EntityItalic, Offset: 0, Length: 2},
+			{Type: EntityUnderline, Offset: 2, Length: 2},
+		},
+	},
+	{
+		name: "emoji",
+		text: "😀 a👍b ğ",
+		entities: []MessageEntity{
+			{Type: EntityBold, Offset: 0, Length: 2},
+			{Type: EntityItalic, Offset: 3, Length: 4},
+			{Type: EntityUnderline, Offset: 8, Length: 1},
+		},
+	},
+	{
+		name: "pre",
+		text: "x := 1\ny := 2",
+		entities: []MessageEntity{
+			{Type: EntityPre, Offset: 0, Length: 13},
+		},
+	},
+	{
+		name: "pre with language",
+		text: "see: fmt.Println(`a\\b`)",
+		entities: []MessageEntity{
+			{Type: EntityPre, Offset: 5, Length: 18, Language: "go"},
+		},
+	},
+	{
+		name: "code",
+		text: "run `go test` <now>",
+		entities: []MessageEntity{
+			{Type: EntityCode, Offset: 4, Length: 9},
+		},
+	},
+	{
+		name: "blockquote",
+		text: "quote\nof two lines\nafter",
+		entities: []MessageEntity{
+			{Type: EntityBlockquote, Offset: 0, Length: 18},
+			{Type: EntityBold, Offset: 9, Length: 3},
+		},
+	},
+	{
+		name: "blockquote ending mid-line",
+		text: "quoted rest",
+		entities: []MessageEntity{
+			{Type: EntityBlockquote, Offset: 0, Length: 6},
+		},
+		markdownV2Text: "quoted\n rest",
+		markdownV2Entities: []MessageEntity{
+			{Type: EntityBlockquote, Offset: 0, Length: 6},
+		},
+	},
+	{
+		name: "blockquote starting mid-line",
+		text: "say quoted\nrest",
+		entities: []MessageEntity{
+			{Type: EntityBlockquote, Offset: 4, Length: 6},
+			{Type: EntityBold, Offset: 11, Length: 4},
+		},
+		markdownV2Text: "say \nquoted\nrest",
+		markdownV2Entities: []MessageEntity{
+			{Type: EntityBlockquote, Offset: 5, Length: 6},
+			{Type: EntityBold, Offset: 12, Length: 4},
+		},
+	},
+	{
+		name: "blockquote with line break",
+		text: "quoted\nrest",
+		entities: []MessageEntity{
+			{Type: EntityBlockquote, Offset: 0, Length: 7},
+		},
+	},
+	{
+		name: "blockquote overlapping bold",
+		text: "quoted rest",
+		entities: []MessageEntity{
+			{Type: EntityBlockquote, Offset: 0, Length: 6},
+			{Type: EntityBold, Offset: 3, Length: 5},
+		},
+		markdownV2Text: "quoted\n rest",
+		markdownV2Entities: []MessageEntity{
+			{Type: EntityBlockquote, Offset: 0, Length: 6},
+			{Type: EntityBold, Offset: 3, Length: 3},
+			{Type: EntityBold, Offset: 7, Length: 2},
+		},
+	},
+	{
+		name: "nested in code",
+		text: "abcdef",
+		entities: []MessageEntity{
+			{Type: EntityCode, Offset: 0, Length: 6},
+			{Type: EntityBold, Offset: 2, Length: 2},
+		},
+	},
+	{
+		name: "overlapping pre",
+		text: "abcdefgh",
+		entities: []MessageEntity{
+			{Type: EntityItalic, Offset: 0, Length: 4},
+			{Type: EntityPre, Offset: 2, Length: 4, Language: "go"},
+			{Type: EntityBold, Offset: 3, Length: 5},
+			{Type: EntityCustomEmoji, Offset: 4, Length: 1, CustomEmojiID: "1"},
+		},
+	},
+	{
+		name: "text link",
+		text: "click here",
+		entities: []MessageEntity{
+			{Type: EntityTextLink, Offset: 6, Length: 4, URL: "https://example.com/a_(b)?c=d&e=f"},
+		},
+	},
+	{
+		name: "text mention",
+		text: "hi you",
+		entities: []MessageEntity{
+			{Type: EntityTextMention, Offset: 3, Length: 3, User: &User{ID: 42}},
+		},
+	},
+	{
+		name: "custom emoji",
+		text: "👍👍 ok",
+		entities: []MessageEntity{
+			{Type: EntityCustomEmoji, Offset: 0, Length: 2, CustomEmojiID: "5368324170671202286"},
+			{Type: EntityCustomEmoji, Offset: 2, Length: 2, CustomEmojiID: "5368324170671202286"},
+		},
+	},
+	{
+		name: "reserved characters",
+		text: "_*[]()~`>#+-=|{}.!\\ <&>\"'",
+		entities: []MessageEntity{
+			{Type: EntityBold, Offset: 0, Length: 19},
+			{Type: EntityItalic, Offset: 20, Length: 5},
+		},
+	},
+	{
+		name: "reserved characters in code",
+		text: "`\\_*<&>",
+		entities: []MessageEntity{
+			{Type: EntityCode, Offset: 0, Length: 7},
+		},
+	},
+}
+
+func TestRenderHTMLRoundTrip(t *testing.T) {
+	for _, tt := range renderTests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := RenderHTML(tt.text, tt.entities)
+			text, entities, err := ParseHTML(s)
+			if err != nil {
+				t.Fatalf("ParseHTML(%q): %v", s, err)
+			}
+			if text != tt.text {
+				t.Errorf("ParseHTML(%q) text = %q, want %q", s, text, tt.text)
+			}
+			want := NormalizeEntities(tt.text, tt.entities)
+			if !reflect.DeepEqual(entities, want) {
+				t.Errorf("ParseHTML(%q) entities = %+v, want %+v", s, entities, want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownV2RoundTrip(t *testing.T) {
+	for _, tt := range renderTests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := RenderMarkdownV2(tt.text, tt.entities)
+			text, entities, err := ParseMarkdownV2(s)
+			if err != nil {
+				t.Fatalf("ParseMarkdownV2(%q): %v", s, err)
+			}
+			wantText, want := tt.text, NormalizeEntities(tt.text, tt.entities)
+			if tt.markdownV2Text != "" {
+				wantText, want = tt.markdownV2Text, tt.markdownV2Entities
+			}
+			if text != wantText {
+				t.Errorf("ParseMarkdownV2(%q) text = %q, want %q", s, text, wantText)
+			}
+			if !reflect.DeepEqual(entities, want) {
+				t.Errorf("ParseMarkdownV2(%q) entities = %+v, want %+v", s, entities, want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	entities := []MessageEntity{
+		{Type: EntityBold, Offset: 0, Length: 4},
+		{Type: EntityItalic, Offset: 2, Length: 4},
+	}
+	if got, want := RenderHTML("abcdef", entities), "<b>ab<i>cd</i></b><i>ef</i>"; got != want {
+		t.Errorf("RenderHTML = %q, want %q", got, want)
+	}
+	if got, want := RenderMarkdownV2("abcdef", entities), "*ab_cd_*_ef_"; got != want {
+		t.Errorf("RenderMarkdownV2 = %q, want %q", got, want)
+	}
+	code := []MessageEntity{
+		{Type: EntityCode, Offset: 0, Length: 6},
+		{Type: EntityBold, Offset: 2, Length: 2},
+	}
+	if got, want := RenderHTML("abcdef", code), "<code>abcdef</code>"; got != want {
+		t.Errorf("RenderHTML = %q, want %q", got, want)
+	}
+	if got, want := RenderMarkdownV2("abcdef", code), "`abcdef`"; got != want {
+		t.Errorf("RenderMarkdownV2 = %q, want %q", got, want)
+	}
+	quote := []MessageEntity{{Type: EntityBlockquote, Offset: 0, Length: 6}}
+	if got, want := RenderMarkdownV2("quoted rest", quote), ">quoted\n rest"; got != want {
+		t.Errorf("RenderMarkdownV2 = %q, want %q", got, want)
+	}
+	if got, want := RenderMarkdownV2("a.b!", nil), `a\.b\!`; got != want {
+		t.Errorf("RenderMarkdownV2 = %q, want %q", got, want)
+	}
+	if got, want := RenderHTML("a<b>&c", nil), "a&lt;b&gt;&amp;c"; got != want {
+		t.Errorf("RenderHTML = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeEntities(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		entities []MessageEntity
+		want     []MessageEntity
+	}{
+		{
+			name: "merge fragments",
+			text: "abcdef",
+			entities: []MessageEntity{
+				{Type: EntityItalic, Offset: 4, Length: 2},
+				{Type: EntityBold, Offset: 0, Length: 4},
+				{Type: EntityItalic, Offset: 2, Length: 2},
+			},
+			want: []MessageEntity{
+				{Type: EntityBold, Offset: 0, Length: 4},
+				{Type: EntityItalic, Offset: 2, Length: 4},
+			},
+		},
+		{
+			name: "merge overlapping",
+			text: "abcdef",
+			entities: []MessageEntity{
+				{Type: EntityBold, Offset: 0, Length: 4},
+				{Type: EntityBold, Offset: 1, Length: 5},
+			},
+			want: []MessageEntity{
+				{Type: EntityBold, Offset: 0, Length: 6},
+			},
+		},
+		{
+			name: "different links",
+			text: "abcd",
+			entities: []MessageEntity{
+				{Type: EntityTextLink, Offset: 0, Length: 2, URL: "https://a.example"},
+				{Type: EntityTextLink, Offset: 2, Length: 2, URL: "https://b.example"},
+			},
+			want: []MessageEntity{
+				{Type: EntityTextLink, Offset: 0, Length: 2, URL: "https://a.example"},
+				{Type: EntityTextLink, Offset: 2, Length: 2, URL: "https://b.example"},
+			},
+		},
+		{
+			name: "custom emoji",
+			text: "👍👍",
+			entities: []MessageEntity{
+				{Type: EntityCustomEmoji, Offset: 0, Length: 2, CustomEmojiID: "1"},
+				{Type: EntityCustomEmoji, Offset: 2, Length: 2, CustomEmojiID: "1"},
+			},
+			want: []MessageEntity{
+				{Type: EntityCustomEmoji, Offset: 0, Length: 2, CustomEmojiID: "1"},
+				{Type: EntityCustomEmoji, Offset: 2, Length: 2, CustomEmojiID: "1"},
+			},
+		},
+		{
+			name: "same span order",
+			text: "abc",
+			entities: []MessageEntity{
+				{Type: EntityCode, Offset: 0, Length: 3},
+				{Type: EntityItalic, Offset: 0, Length: 3},
+				{Type: EntityTextLink, Offset: 0, Length: 3, URL: "https://example.com"},
+				{Type: EntityBold, Offset: 0, Length: 3},
+			},
+			want: []MessageEntity{
+				{Type: EntityTextLink, Offset: 0, Length: 3, URL: "https://example.com"},
+				{Type: EntityBold, Offset: 0, Length: 3},
+				{Type: EntityItalic, Offset: 0, Length: 3},
+				{Type: EntityCode, Offset: 0, Length: 3},
+			},
+		},
+		{
+			name: "clip and drop",
+			text: "a😀",
+			entities: []MessageEntity{
+				{Type: EntityBold, Offset: -1, Length: 2},
+				{Type: EntityItalic, Offset: 1, Length: 10},
+				{Type: EntityUnderline, Offset: 3, Length: 2},
+				{Type: EntitySpoiler, Offset: 1, Length: 0},
+			},
+			want: []MessageEntity{
+				{Type: EntityBold, Offset: 0, Length: 1},
+				{Type: EntityItalic, Offset: 1, Length: 2},
+			},
+		},
+		{
+			name: "empty",
+			text: "abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeEntities(tt.text, tt.entities)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeEntities = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
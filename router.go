@@ -0,0 +1,234 @@
+package telegram
+
+import (
+	"strings"
+	"sync"
+)
+
+// Router dispatches incoming updates to handlers registered by update kind,
+// as an alternative to reading the Messages and Updates channels directly.
+// Updates without a registered handler are passed to the default handler, if
+// any, or dropped.
+//
+//	r := telegram.NewRouter()
+//	r.Command("start", "Start the bot", func(m *telegram.Message) { ... })
+//	r.OnMessage(func(m *telegram.Message) { ... })
+//	r.OnCallbackQuery(func(q *telegram.CallbackQuery) { ... })
+//	r.OnChatMember(func(u *telegram.ChatMemberUpdated) { ... })
+//	if err := r.PublishCommands(bot, nil, ""); err != nil { ... }
+//	go r.Listen(bot)
+//
+// Handlers are called one at a time, in the order the updates arrive. Long
+// running handlers should start their own goroutines. Router is safe for
+// concurrent use.
+type Router struct {
+	mu       sync.RWMutex
+	handlers routerHandlers
+}
+
+// routerHandlers holds the handlers of a Router, so that they can be copied
+// at once.
+type routerHandlers struct {
+	message         func(*Message)
+	editedMessage   func(*Message)
+	callbackQuery   func(*CallbackQuery)
+	inlineQuery     func(*InlineQuery)
+	chatMember      func(*ChatMemberUpdated)
+	myChatMember    func(*ChatMemberUpdated)
+	chatJoinRequest func(*ChatJoinRequest)
+	messageReaction func(*MessageReactionUpdated)
+	fallback        func(*Update)
+
+	// commands and commandList are replaced, never modified, so that the
+	// copies taken by Dispatch stay valid.
+	commands    map[string]func(*Message)
+	commandList []BotCommand
+}
+
+// NewRouter returns a new Router without any handlers.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// OnMessage registers the handler of new incoming messages.
+func (r *Router) OnMessage(h func(*Message)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers.message = h
+}
+
+// OnEditedMessage registers the handler of edited messages.
+func (r *Router) OnEditedMessage(h func(*Message)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers.editedMessage = h
+}
+
+// OnCallbackQuery registers the handler of callback queries, sent from inline
+// keyboard buttons.
+func (r *Router) OnCallbackQuery(h func(*CallbackQuery)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers.callbackQuery = h
+}
+
+// OnInlineQuery registers the handler of inline queries.
+func (r *Router) OnInlineQuery(h func(*InlineQuery)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers.inlineQuery = h
+}
+
+// OnChatMember registers the handler of changes in the status of chat
+// members. These updates are not delivered unless "chat_member" is passed to
+// SetWebhook in allowed updates.
+func (r *Router) OnChatMember(h func(*ChatMemberUpdated)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers.chatMember = h
+}
+
+// OnMyChatMember registers the handler of changes in the status of the bot
+// in chats, such as being blocked or added to a group.
+func (r *Router) OnMyChatMember(h func(*ChatMemberUpdated)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers.myChatMember = h
+}
+
+// OnChatJoinRequest registers the handler of chat join requests.
+func (r *Router) OnChatJoinRequest(h func(*ChatJoinRequest)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers.chatJoinRequest = h
+}
+
+// OnMessageReaction registers the handler of changes of reactions on
+// messages.
+func (r *Router) OnMessageReaction(h func(*MessageReactionUpdated)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers.messageReaction = h
+}
+
+// Command registers the handler of messages which start with the bot command
+// /name, which may be addressed to the bot as /name@username. These messages
+// are not passed to the handler registered with OnMessage. The description is
+// shown in the command menu of Telegram clients once the commands are
+// published with PublishCommands. Registering a command again replaces its
+// handler and description.
+func (r *Router) Command(name, description string, h func(*Message)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	commands := make(map[string]func(*Message), len(r.handlers.commands)+1)
+	for k, v := range r.handlers.commands {
+		commands[k] = v
+	}
+	commands[name] = h
+
+	list := make([]BotCommand, 0, len(r.handlers.commandList)+1)
+	found := false
+	for _, c := range r.handlers.commandList {
+		if c.Command == name {
+			c.Description = description
+			found = true
+		}
+		list = append(list, c)
+	}
+	if !found {
+		list = append(list, BotCommand{Command: name, Description: description})
+	}
+
+	r.handlers.commands = commands
+	r.handlers.commandList = list
+}
+
+// Commands returns the registered commands, in the order they were first
+// registered.
+func (r *Router) Commands() []BotCommand {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]BotCommand(nil), r.handlers.commandList...)
+}
+
+// PublishCommands sets the registered commands as the commands of the bot for
+// the given scope and language, see Bot.SetMyCommands. It is usually called
+// once at startup, after the commands are registered.
+func (r *Router) PublishCommands(b *Bot, scope BotCommandScope, languageCode string) error {
+	return b.SetMyCommands(r.Commands(), scope, languageCode)
+}
+
+// Default registers the handler of updates which have no handler registered
+// for their kind.
+func (r *Router) Default(h func(*Update)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers.fallback = h
+}
+
+// Dispatch calls the handler registered for the kind of the update. Handlers
+// are called without holding the lock of the router, so that they can
+// register or replace handlers.
+func (r *Router) Dispatch(u *Update) {
+	r.mu.RLock()
+	h := r.handlers
+	r.mu.RUnlock()
+
+	switch {
+	case u.Message.ID != 0 && h.commands[commandName(u.Message.Text)] != nil:
+		h.commands[commandName(u.Message.Text)](&u.Message)
+	case u.Message.ID != 0 && h.message != nil:
+		h.message(&u.Message)
+	case u.EditedMessage.ID != 0 && h.editedMessage != nil:
+		h.editedMessage(&u.EditedMessage)
+	case u.CallbackQuery != nil && h.callbackQuery != nil:
+		h.callbackQuery(u.CallbackQuery)
+	case u.InlineQuery != nil && h.inlineQuery != nil:
+		h.inlineQuery(u.InlineQuery)
+	case u.ChatMember != nil && h.chatMember != nil:
+		h.chatMember(u.ChatMember)
+	case u.MyChatMember != nil && h.myChatMember != nil:
+		h.myChatMember(u.MyChatMember)
+	case u.ChatJoinRequest != nil && h.chatJoinRequest != nil:
+		h.chatJoinRequest(u.ChatJoinRequest)
+	case u.MessageReaction != nil && h.messageReaction != nil:
+		h.messageReaction(u.MessageReaction)
+	case h.fallback != nil:
+		h.fallback(u)
+	}
+}
+
+// Listen receives updates from the bot's Messages and Updates channels and
+// dispatches them. It never returns, so it is usually run in its own
+// goroutine.
+func (r *Router) Listen(b *Bot) {
+	messages := b.Messages()
+	updates := b.Updates()
+	for {
+		select {
+		case m := <-messages:
+			r.Dispatch(&Update{Message: *m})
+		case u := <-updates:
+			r.Dispatch(u)
+		}
+	}
+}
+
+// commandName returns the name of the bot command text starts with, without
+// the leading slash and the username of the bot, or an empty string if text
+// doesn't start with a command.
+func commandName(text string) string {
+	if !strings.HasPrefix(text, "/") {
+		return ""
+	}
+	name := text[1:]
+	if i := strings.IndexAny(name, " \t\n"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
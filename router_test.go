@@ -0,0 +1,129 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRouterDispatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		update Update
+		want   string
+	}{
+		{"message", Update{Message: Message{ID: 1}}, "message"},
+		{"edited message", Update{EditedMessage: Message{ID: 1}}, "edited"},
+		{"callback query", Update{CallbackQuery: &CallbackQuery{ID: "q"}}, "callback"},
+		{"chat member", Update{ChatMember: &ChatMemberUpdated{}}, "member"},
+		{"unhandled kind", Update{Poll: &Poll{}}, "default"},
+	}
+
+	var got string
+	r := NewRouter()
+	r.OnMessage(func(*Message) { got = "message" })
+	r.OnEditedMessage(func(*Message) { got = "edited" })
+	r.OnCallbackQuery(func(*CallbackQuery) { got = "callback" })
+	r.OnChatMember(func(*ChatMemberUpdated) { got = "member" })
+	r.Default(func(*Update) { got = "default" })
+
+	for _, tt := range tests {
+		got = ""
+		u := tt.update
+		r.Dispatch(&u)
+		if got != tt.want {
+			t.Errorf("%v: dispatched to %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRouterReregister(t *testing.T) {
+	var got []string
+	r := NewRouter()
+	r.OnMessage(func(*Message) {
+		got = append(got, "first")
+		r.OnMessage(func(*Message) { got = append(got, "second") })
+		r.Default(func(*Update) { got = append(got, "default") })
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Dispatch(&Update{Message: Message{ID: 1}})
+		r.Dispatch(&Update{Message: Message{ID: 2}})
+		r.Dispatch(&Update{Poll: &Poll{}})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Dispatch deadlocked when a handler registered a handler")
+	}
+
+	want := []string{"first", "second", "default"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dispatched to %v, want %v", got, want)
+	}
+}
+
+func TestRouterCommand(t *testing.T) {
+	var got string
+	r := NewRouter()
+	r.OnMessage(func(*Message) { got = "message" })
+	r.Command("start", "Start", func(*Message) { got = "start" })
+	r.Command("help", "Help", func(*Message) { got = "help" })
+	r.Command("start", "Start the bot", func(*Message) { got = "start again" })
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"/start", "start again"},
+		{"/help me", "help"},
+		{"/help@examplebot", "help"},
+		{"/help\nme", "help"},
+		{"/unknown", "message"},
+		{"help", "message"},
+		{"", "message"},
+	}
+	for _, tt := range tests {
+		got = ""
+		r.Dispatch(&Update{Message: Message{ID: 1, Text: tt.text}})
+		if got != tt.want {
+			t.Errorf("%q: dispatched to %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	want := []BotCommand{
+		{Command: "start", Description: "Start the bot"},
+		{Command: "help", Description: "Help"},
+	}
+	if got := r.Commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Commands() = %+v, want %+v", got, want)
+	}
+
+	var published []BotCommand
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/setMyCommands" {
+			t.Errorf("called %v, want setMyCommands", req.URL.Path)
+		}
+		if err := json.Unmarshal([]byte(req.FormValue("commands")), &published); err != nil {
+			t.Errorf("malformed commands: %v", err)
+		}
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}))
+	defer srv.Close()
+
+	b := New("token")
+	b.baseURL = srv.URL + "/"
+	if err := r.PublishCommands(b, nil, ""); err != nil {
+		t.Fatalf("PublishCommands: %v", err)
+	}
+	if !reflect.DeepEqual(published, want) {
+		t.Errorf("published %+v, want %+v", published, want)
+	}
+}
@@ -0,0 +1,199 @@
+package telegram
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// MaxMessageLength is the maximum length of a message text, in UTF-16 code
+// units after entities parsing.
+const MaxMessageLength = 4096
+
+// SendLongMessage sends message to the recipient like SendMessage, splitting
+// it into several messages if it is longer than MaxMessageLength. The parts
+// are sent in order and all the sent messages are returned. If sending a part
+// fails, the messages sent so far are returned along with the error.
+//
+// The message is split on paragraph, line or word boundaries, in that order
+// of preference, and never inside an entity unless the entity itself doesn't
+// fit into a message. Messages formatted with ModeHTML or ModeMarkdownV2 are
+// parsed and the parts are sent with entities instead, so that no tag is cut
+// in half. Messages formatted with the legacy ModeMarkdown can't be split.
+//
+// The reply set by WithReplyTo or WithReplyParameters applies to the first
+// part only, and the reply markup set by WithReplyMarkup to the last part
+// only.
+func (b *Bot) SendLongMessage(recipient int64, message string, opts ...SendOption) ([]Message, error) {
+	var o sendOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	var text string
+	var entities []MessageEntity
+	var err error
+	switch o.parseMode {
+	case ModeNone:
+		text, entities = message, o.entities
+	case ModeHTML:
+		text, entities, err = ParseHTML(message)
+	case ModeMarkdownV2:
+		text, entities, err = ParseMarkdownV2(message)
+	default:
+		if utf16Len(message) <= MaxMessageLength {
+			msg, err := b.SendMessage(recipient, message, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return []Message{msg}, nil
+		}
+		err = fmt.Errorf("telegram: can't split message with parse mode %v", o.parseMode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	parts := SplitText(text, entities, MaxMessageLength)
+	msgs := make([]Message, 0, len(parts))
+	for i, part := range parts {
+		partOpts := append(opts[:len(opts):len(opts)], WithParseMode(ModeNone), WithEntities(part.Entities()))
+		if i > 0 {
+			partOpts = append(partOpts, WithReplyParameters(ReplyParameters{}))
+		}
+		if i < len(parts)-1 {
+			partOpts = append(partOpts, WithReplyMarkup(ReplyMarkup{}))
+		}
+
+		msg, err := b.SendMessage(recipient, part.String(), partOpts...)
+		if err != nil {
+			return msgs, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// SplitText splits text with the given entities into parts which are at most
+// limit UTF-16 code units long, as SendLongMessage does. Entities are adjusted
+// to the parts they fall into. The whitespace the text is split on is
+// dropped, unless the text has to be cut inside a word or an entity.
+func SplitText(text string, entities []MessageEntity, limit int) []Text {
+	if limit <= 0 {
+		limit = MaxMessageLength
+	}
+
+	// UTF-16 position and byte index of each rune, plus the end of text.
+	var runes []rune
+	var pos, idx []int
+	n := 0
+	for i, r := range text {
+		runes = append(runes, r)
+		pos = append(pos, n)
+		idx = append(idx, i)
+		n += utf16RuneLen(r)
+	}
+	pos = append(pos, n)
+	idx = append(idx, len(text))
+
+	// insideEntity reports whether UTF-16 position p falls strictly inside an
+	// entity, so that splitting there would cut the entity in half.
+	insideEntity := func(p int) bool {
+		for _, e := range entities {
+			if e.Offset < p && p < e.Offset+e.Length {
+				return true
+			}
+		}
+		return false
+	}
+
+	isParagraph := func(i int) bool { return runes[i] == '\n' && i > 0 && runes[i-1] == '\n' }
+	isLine := func(i int) bool { return runes[i] == '\n' }
+	isWord := func(i int) bool { return unicode.IsSpace(runes[i]) }
+
+	var parts []Text
+	start := 0 // index of the first rune of the current part
+	for {
+		if pos[len(runes)]-pos[start] <= limit {
+			parts = append(parts, cutText(text, entities, idx[start], idx[len(runes)], pos[start], pos[len(runes)]))
+			return parts
+		}
+
+		// index of the first rune which doesn't fit into the part.
+		last := start
+		for last < len(runes) && pos[last+1]-pos[start] <= limit {
+			last++
+		}
+
+		// split is the index of the rune the next part starts with. If the
+		// text is split on a separator, split is the index of the separator,
+		// which is dropped along with the whitespace around it.
+		split := -1
+		for _, fits := range []func(int) bool{isParagraph, isLine, isWord} {
+			for i := last; i > start && pos[i]-pos[start] > limit/2; i-- {
+				if fits(i) && !insideEntity(pos[i]) {
+					split = i
+					break
+				}
+			}
+			if split >= 0 {
+				break
+			}
+		}
+		sep := split >= 0
+		if split < 0 {
+			for i := last; i > start; i-- {
+				if !insideEntity(pos[i]) {
+					split = i
+					break
+				}
+			}
+		}
+		if split < 0 {
+			split = last
+			if split == start {
+				split++
+			}
+		}
+
+		end := split
+		for sep && end > start && unicode.IsSpace(runes[end-1]) {
+			end--
+		}
+		if end > start {
+			parts = append(parts, cutText(text, entities, idx[start], idx[end], pos[start], pos[end]))
+		}
+
+		start = split
+		for sep && start < len(runes) && unicode.IsSpace(runes[start]) {
+			start++
+		}
+		if start == len(runes) {
+			return parts
+		}
+	}
+}
+
+// cutText returns the part of text between the byte indices i and j, which
+// correspond to the UTF-16 positions p and q, with the entities clipped to
+// it.
+func cutText(text string, entities []MessageEntity, i, j, p, q int) Text {
+	t := Text{text: []byte(text[i:j]), n: q - p}
+	for _, e := range entities {
+		start, end := e.Offset, e.Offset+e.Length
+		if start < p {
+			start = p
+		}
+		if end > q {
+			end = q
+		}
+		if start >= end {
+			continue
+		}
+		e.Offset = start - p
+		e.Length = end - start
+		t.entities = append(t.entities, e)
+	}
+	return t
+}
@@ -0,0 +1,139 @@
+package telegram
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitText(t *testing.T) {
+	type part struct {
+		text     string
+		entities []MessageEntity
+	}
+	tests := []struct {
+		name     string
+		text     string
+		entities []MessageEntity
+		limit    int
+		want     []part
+	}{
+		{
+			name:     "short",
+			text:     "hello world",
+			entities: []MessageEntity{{Type: EntityBold, Offset: 6, Length: 5}},
+			limit:    0,
+			want: []part{
+				{"hello world", []MessageEntity{{Type: EntityBold, Offset: 6, Length: 5}}},
+			},
+		},
+		{
+			name:  "paragraph",
+			text:  "aaaa bbbb\n\ncccc dddd",
+			limit: 15,
+			want: []part{
+				{"aaaa bbbb", nil},
+				{"cccc dddd", nil},
+			},
+		},
+		{
+			name:  "line",
+			text:  "aaaa bbbb\ncccc dddd",
+			limit: 12,
+			want: []part{
+				{"aaaa bbbb", nil},
+				{"cccc dddd", nil},
+			},
+		},
+		{
+			name:  "word",
+			text:  "aaaa bbbb cccc",
+			limit: 10,
+			want: []part{
+				{"aaaa bbbb", nil},
+				{"cccc", nil},
+			},
+		},
+		{
+			name: "shift offsets",
+			text: "aaaa bbbb cccc",
+			entities: []MessageEntity{
+				{Type: EntityBold, Offset: 0, Length: 4},
+				{Type: EntityTextLink, Offset: 10, Length: 4, URL: "https://example.com"},
+			},
+			limit: 10,
+			want: []part{
+				{"aaaa bbbb", []MessageEntity{{Type: EntityBold, Offset: 0, Length: 4}}},
+				{"cccc", []MessageEntity{{Type: EntityTextLink, Offset: 0, Length: 4, URL: "https://example.com"}}},
+			},
+		},
+		{
+			name:     "keep entity intact",
+			text:     "aa bb cc dd",
+			entities: []MessageEntity{{Type: EntityBold, Offset: 6, Length: 5}},
+			limit:    8,
+			want: []part{
+				{"aa bb", nil},
+				{"cc dd", []MessageEntity{{Type: EntityBold, Offset: 0, Length: 5}}},
+			},
+		},
+		{
+			name:  "utf-16 limit",
+			text:  "😀😀😀😀",
+			limit: 4,
+			want: []part{
+				{"😀😀", nil},
+				{"😀😀", nil},
+			},
+		},
+		{
+			name:     "utf-16 offsets",
+			text:     "😀😀 ğğ😀",
+			entities: []MessageEntity{{Type: EntityItalic, Offset: 5, Length: 4}},
+			limit:    6,
+			want: []part{
+				{"😀😀", nil},
+				{"ğğ😀", []MessageEntity{{Type: EntityItalic, Offset: 0, Length: 4}}},
+			},
+		},
+		{
+			name:     "cut long entity",
+			text:     "abcdefgh",
+			entities: []MessageEntity{{Type: EntityBold, Offset: 0, Length: 8}},
+			limit:    3,
+			want: []part{
+				{"abc", []MessageEntity{{Type: EntityBold, Offset: 0, Length: 3}}},
+				{"def", []MessageEntity{{Type: EntityBold, Offset: 0, Length: 3}}},
+				{"gh", []MessageEntity{{Type: EntityBold, Offset: 0, Length: 2}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := SplitText(tt.text, tt.entities, tt.limit)
+			var got []part
+			for _, p := range parts {
+				got = append(got, part{p.String(), p.Entities()})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitText(%q, %v) = %+v, want %+v", tt.text, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitTextLimit(t *testing.T) {
+	text := strings.Repeat("😀 word\n", 1000) + strings.Repeat("x", 5000)
+	parts := SplitText(text, nil, 0)
+	if len(parts) < 2 {
+		t.Fatalf("SplitText returned %v parts, want several", len(parts))
+	}
+	for i, p := range parts {
+		if p.Len() > MaxMessageLength {
+			t.Errorf("part %v is %v long, want at most %v", i, p.Len(), MaxMessageLength)
+		}
+		if got := utf16Len(p.String()); got != p.Len() {
+			t.Errorf("part %v Len() = %v, want %v", i, p.Len(), got)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// StickerType is the type of stickers in a sticker set.
+type StickerType string
+
+// Types of stickers
+const (
+	StickerRegular     StickerType = "regular"
+	StickerMask        StickerType = "mask"
+	StickerCustomEmoji StickerType = "custom_emoji"
+)
+
+// StickerFormat is the format of a sticker file.
+type StickerFormat string
+
+// Formats of sticker files
+const (
+	// A .webp or .png image
+	StickerStatic StickerFormat = "static"
+
+	// A .tgs animation
+	StickerAnimated StickerFormat = "animated"
+
+	// A .webm video
+	StickerVideo StickerFormat = "video"
+)
+
+// MaskPoint is the part of the face relative to which a mask should be
+// placed.
+type MaskPoint string
+
+// Parts of the face to place masks on
+const (
+	MaskForehead MaskPoint = "forehead"
+	MaskEyes     MaskPoint = "eyes"
+	MaskMouth    MaskPoint = "mouth"
+	MaskChin     MaskPoint = "chin"
+)
+
+// MaskPosition describes the position on faces where a mask should be placed
+// by default.
+type MaskPosition struct {
+	// The part of the face relative to which the mask should be placed
+	Point MaskPoint `json:"point"`
+
+	// Shift by X-axis measured in widths of the mask scaled to the face size,
+	// from left to right. For example, choosing -1.0 will place mask just to
+	// the left of the default mask position
+	XShift float64 `json:"x_shift"`
+
+	// Shift by Y-axis measured in heights of the mask scaled to the face size,
+	// from top to bottom. For example, 1.0 will place the mask just below the
+	// default mask position
+	YShift float64 `json:"y_shift"`
+
+	// Mask scaling coefficient. For example, 2.0 means double size
+	Scale float64 `json:"scale"`
+}
+
+// StickerSet represents a sticker set.
+type StickerSet struct {
+	// Sticker set name
+	Name string `json:"name"`
+
+	// Sticker set title
+	Title string `json:"title"`
+
+	// Type of stickers in the set
+	StickerType StickerType `json:"sticker_type"`
+
+	// List of all set stickers
+	Stickers []Sticker `json:"stickers"`
+
+	// Optional. Sticker set thumbnail in the .webp, .tgs, or .webm format
+	Thumbnail *Photo `json:"thumbnail,omitempty"`
+}
+
+// InputSticker describes a sticker to be added to a sticker set.
+type InputSticker struct {
+	// The sticker file. It is referred to by its file ID or URL if set,
+	// otherwise it is uploaded. Animated and video stickers can't be
+	// referred to by URL
+	Sticker File `json:"-"`
+
+	// Format of the sticker file
+	Format StickerFormat `json:"format"`
+
+	// List of 1-20 emoji associated with the sticker
+	EmojiList []string `json:"emoji_list"`
+
+	// Optional. Position where the mask should be placed on faces, for mask
+	// stickers only
+	MaskPosition *MaskPosition `json:"mask_position,omitempty"`
+
+	// Optional. List of 0-20 search keywords for the sticker with total length
+	// of up to 64 characters, for regular and custom emoji stickers only
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s InputSticker) MarshalJSON() ([]byte, error) {
+	type sticker InputSticker
+	file := s.Sticker.FileID
+	if file == "" {
+		file = s.Sticker.URL
+	}
+	return json.Marshal(struct {
+		Sticker string `json:"sticker"`
+		sticker
+	}{file, sticker(s)})
+}
+
+// GetStickerSet retrieves the sticker set with the given name.
+func (b *Bot) GetStickerSet(name string) (StickerSet, error) {
+	const method = "getStickerSet"
+	params := url.Values{}
+	params.Set("name", name)
+
+	var r struct {
+		response
+		Set StickerSet `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return StickerSet{}, err
+	}
+
+	if !r.OK {
+		return StickerSet{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Set, nil
+}
+
+// GetCustomEmojiStickers retrieves the stickers of the custom emoji with the
+// given identifiers. At most 200 custom emoji identifiers can be specified.
+func (b *Bot) GetCustomEmojiStickers(customEmojiIDs []string) ([]Sticker, error) {
+	const method = "getCustomEmojiStickers"
+	params := url.Values{}
+	if customEmojiIDs == nil {
+		customEmojiIDs = []string{}
+	}
+	ids, err := json.Marshal(customEmojiIDs)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("custom_emoji_ids", string(ids))
+
+	var r struct {
+		response
+		Stickers []Sticker `json:"result"`
+	}
+	err = b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.OK {
+		return nil, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Stickers, nil
+}
+
+// UploadStickerFile uploads a sticker file in the given format, to be used
+// later in CreateNewStickerSet and AddStickerToSet. userID is the identifier
+// of the sticker file owner. The returned file can be used multiple times.
+func (b *Bot) UploadStickerFile(userID int64, sticker File, format StickerFormat) (File, error) {
+	const method = "uploadStickerFile"
+	params := url.Values{}
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+	params.Set("sticker_format", string(format))
+
+	var r struct {
+		response
+		File File `json:"result"`
+	}
+	err := b.sendFile(method, sticker, "sticker", params, &r)
+	if err != nil {
+		return File{}, err
+	}
+
+	if !r.OK {
+		return File{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.File, nil
+}
+
+// CreateNewStickerSet creates a new sticker set owned by the user with the
+// given ID, containing 1-50 stickers. The bot will be able to edit the
+// sticker set thus created. name can contain only English letters, digits and
+// underscores, and must end in “_by_<bot_username>”. If needsRepainting is
+// true, the stickers of a custom emoji set are repainted to the color of the
+// text they are used in. Sticker files which are not on Telegram servers yet
+// are uploaded first.
+func (b *Bot) CreateNewStickerSet(userID int64, name, title string, stickers []InputSticker, stickerType StickerType, needsRepainting bool) error {
+	const method = "createNewStickerSet"
+	params := url.Values{}
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+	params.Set("name", name)
+	params.Set("title", title)
+
+	input := make([]InputSticker, len(stickers))
+	for i, s := range stickers {
+		var err error
+		input[i], err = b.uploadInputSticker(userID, s)
+		if err != nil {
+			return err
+		}
+	}
+	ss, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	params.Set("stickers", string(ss))
+
+	if stickerType != "" {
+		params.Set("sticker_type", string(stickerType))
+	}
+
+	if needsRepainting {
+		params.Set("needs_repainting", "true")
+	}
+
+	var r response
+	err = b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// AddStickerToSet adds a new sticker to the set with the given name, created
+// by the bot and owned by the user with the given ID. Emoji sticker sets can
+// have up to 200 stickers and other sticker sets up to 120 stickers. The
+// sticker file is uploaded first if it is not on Telegram servers yet.
+func (b *Bot) AddStickerToSet(userID int64, name string, sticker InputSticker) error {
+	const method = "addStickerToSet"
+	params := url.Values{}
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+	params.Set("name", name)
+
+	sticker, err := b.uploadInputSticker(userID, sticker)
+	if err != nil {
+		return err
+	}
+	s, err := json.Marshal(sticker)
+	if err != nil {
+		return err
+	}
+	params.Set("sticker", string(s))
+
+	var r response
+	err = b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// SetStickerPositionInSet moves a sticker in a set created by the bot to the
+// given zero-based position.
+func (b *Bot) SetStickerPositionInSet(sticker string, position int) error {
+	params := url.Values{}
+	params.Set("position", strconv.Itoa(position))
+	return b.stickerCommand("setStickerPositionInSet", sticker, params)
+}
+
+// DeleteStickerFromSet deletes a sticker from a set created by the bot.
+func (b *Bot) DeleteStickerFromSet(sticker string) error {
+	return b.stickerCommand("deleteStickerFromSet", sticker, url.Values{})
+}
+
+// SetStickerEmojiList changes the list of emoji assigned to a regular or
+// custom emoji sticker. The sticker must belong to a sticker set created by
+// the bot.
+func (b *Bot) SetStickerEmojiList(sticker string, emojiList []string) error {
+	params := url.Values{}
+	if emojiList == nil {
+		emojiList = []string{}
+	}
+	emoji, err := json.Marshal(emojiList)
+	if err != nil {
+		return err
+	}
+	params.Set("emoji_list", string(emoji))
+	return b.stickerCommand("setStickerEmojiList", sticker, params)
+}
+
+// stickerCommand calls one of the methods which act on a sticker in a set,
+// identified by its file ID.
+func (b *Bot) stickerCommand(method, sticker string, params url.Values) error {
+	params.Set("sticker", sticker)
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// uploadInputSticker uploads the file of the given sticker unless it is
+// already at Telegram servers or referred to by a URL, and returns the
+// sticker with the uploaded file.
+func (b *Bot) uploadInputSticker(userID int64, s InputSticker) (InputSticker, error) {
+	if s.Sticker.Exists() || s.Sticker.URL != "" {
+		return s, nil
+	}
+
+	f, err := b.UploadStickerFile(userID, s.Sticker, s.Format)
+	if err != nil {
+		return InputSticker{}, err
+	}
+	s.Sticker = f
+	return s, nil
+}
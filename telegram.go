@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,6 +21,10 @@ type Bot struct {
 	baseURL   string
 	client    *http.Client
 	messageCh chan *Message
+
+	mu       sync.Mutex
+	updateCh chan *Update // created on first call to Updates
+	me       *User        // cached result of GetMe
 }
 
 // New creates a new Telegram bot with the given token, which is given by
@@ -33,24 +38,60 @@ func New(token string) *Bot {
 	}
 }
 
+// Handler returns an http.HandlerFunc which receives updates sent by Telegram
+// to the bot's webhook. New messages are delivered to the Messages channel.
+// All other updates are delivered to the Updates channel. See Router for
+// dispatching them to handlers by update kind.
 func (b *Bot) Handler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer w.WriteHeader(http.StatusOK)
 
 		var u Update
 		_ = json.NewDecoder(r.Body).Decode(&u)
-		b.messageCh <- &u.Message
+		if u.Message.ID != 0 {
+			b.messageCh <- &u.Message
+			return
+		}
+
+		b.mu.Lock()
+		ch := b.updateCh
+		b.mu.Unlock()
+		if ch != nil {
+			ch <- &u
+		}
 	}
 }
 
+// Messages returns the channel of new incoming messages.
 func (b *Bot) Messages() <-chan *Message {
 	return b.messageCh
 }
 
+// Updates returns the channel of incoming updates which are not new messages,
+// such as chat join requests. Until Updates is called for the first time,
+// such updates are dropped, so bots that only care about messages don't need
+// to drain it.
+func (b *Bot) Updates() <-chan *Update {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.updateCh == nil {
+		b.updateCh = make(chan *Update)
+	}
+	return b.updateCh
+}
+
 // SetWebhook assigns bot's webhook URL with the given URL.
-func (b *Bot) SetWebhook(webhook string) error {
+//
+// allowedUpdates lists the update types the bot wants to receive, such as
+// "message" or "chat_member". If none is given, the previous setting is kept.
+// Note that "chat_member" updates are not delivered unless explicitly listed.
+func (b *Bot) SetWebhook(webhook string, allowedUpdates ...string) error {
 	params := url.Values{}
 	params.Set("url", webhook)
+	if len(allowedUpdates) > 0 {
+		allowed, _ := json.Marshal(allowedUpdates)
+		params.Set("allowed_updates", string(allowed))
+	}
 
 	var r response
 	err := b.sendCommand(nil, "setWebhook", params, &r)
@@ -117,6 +158,7 @@ func (b *Bot) SendPhoto(recipient int64, photo Photo, opts ...SendOption) (Messa
 	params.Set("caption", photo.Caption)
 
 	mapSendOptions(&params, opts...)
+	mapCaptionEntities(&params)
 	var r struct {
 		response
 		Message Message `json:"result"`
@@ -154,6 +196,7 @@ func (b *Bot) SendAudio(recipient int64, audio Audio, opts ...SendOption) (Messa
 	params.Set("caption", audio.Caption)
 
 	mapSendOptions(&params, opts...)
+	mapCaptionEntities(&params)
 	var r struct {
 		response
 		Message Message `json:"result"`
@@ -187,10 +230,44 @@ func (b *Bot) sendDocument(recipient int64, document Document, opts ...SendOptio
 	panic("TODO")
 }
 
-//SendSticker sends stickers with .webp extensions.
-func (b *Bot) sendSticker(recipient int64, sticker Sticker, opts ...SendOption) (Message, error) {
+// SendSticker sends static .webp, animated .tgs or video .webm stickers. The
+// sticker is sent by its file ID or URL if set, otherwise the file is uploaded.
+// The Emoji field of an uploaded sticker is used as the emoji associated with
+// it.
+func (b *Bot) SendSticker(recipient int64, sticker Sticker, opts ...SendOption) (Message, error) {
 	const method = "sendSticker"
-	panic("TODO")
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(recipient, 10))
+
+	mapSendOptions(&params, opts...)
+	var r struct {
+		response
+		Message Message `json:"result"`
+	}
+
+	var err error
+	if sticker.Exists() {
+		params.Set("sticker", sticker.FileID)
+		err = b.sendCommand(nil, method, params, &r)
+	} else if sticker.URL != "" {
+		params.Set("sticker", sticker.URL)
+		err = b.sendCommand(nil, method, params, &r)
+	} else {
+		if sticker.Emoji != "" {
+			params.Set("emoji", sticker.Emoji)
+		}
+		err = b.sendFile(method, sticker.File, "sticker", params, &r)
+	}
+
+	if err != nil {
+		return Message{}, err
+	}
+
+	if !r.OK {
+		return Message{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Message, nil
 }
 
 // SendVideo sends video files. Telegram clients support mp4 videos (other
@@ -214,13 +291,15 @@ func (b *Bot) sendVideoNote(recipient int64, videonote VideoNote, opts ...SendOp
 	panic("TODO")
 }
 
-// SendLocation sends location point on the map.
+// SendLocation sends location point on the map. If LivePeriod of location is
+// set, a live location is sent, which can be updated with
+// EditMessageLiveLocation until the period expires or it is stopped with
+// StopMessageLiveLocation.
 func (b *Bot) SendLocation(recipient int64, location Location, opts ...SendOption) (Message, error) {
 	const method = "sendLocation"
 	params := url.Values{}
 	params.Set("chat_id", strconv.FormatInt(recipient, 10))
-	params.Set("latitude", strconv.FormatFloat(location.Lat, 'f', -1, 64))
-	params.Set("longitude", strconv.FormatFloat(location.Long, 'f', -1, 64))
+	mapLocation(&params, location)
 
 	mapSendOptions(&params, opts...)
 
@@ -240,6 +319,57 @@ func (b *Bot) SendLocation(recipient int64, location Location, opts ...SendOptio
 	return r.Message, nil
 }
 
+// EditMessageLiveLocation edits a live location message sent by the bot. A
+// location can be edited until its LivePeriod expires or editing is
+// explicitly disabled by a call to StopMessageLiveLocation. If LivePeriod of
+// location is set, it replaces the current period of the live location.
+func (b *Bot) EditMessageLiveLocation(chatID, messageID int64, location Location) (Message, error) {
+	const method = "editMessageLiveLocation"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("message_id", strconv.FormatInt(messageID, 10))
+	mapLocation(&params, location)
+
+	var r struct {
+		response
+		Message Message `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return Message{}, err
+	}
+
+	if !r.OK {
+		return Message{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Message, nil
+}
+
+// StopMessageLiveLocation stops updating a live location message sent by the
+// bot before its live period expires.
+func (b *Bot) StopMessageLiveLocation(chatID, messageID int64) (Message, error) {
+	const method = "stopMessageLiveLocation"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("message_id", strconv.FormatInt(messageID, 10))
+
+	var r struct {
+		response
+		Message Message `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return Message{}, err
+	}
+
+	if !r.OK {
+		return Message{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Message, nil
+}
+
 // SendVenue sends information about a venue.
 func (b *Bot) SendVenue(recipient int64, venue Venue, opts ...SendOption) (Message, error) {
 	const method = "sendVenue"
@@ -267,9 +397,65 @@ func (b *Bot) SendVenue(recipient int64, venue Venue, opts ...SendOption) (Messa
 	return r.Message, nil
 }
 
-func (b *Bot) sendContact(recipient int64, contact Contact, opts ...SendOption) (Message, error) {
+// SendContact sends a phone contact. Additional data about the contact can be
+// given in the form of a vCard via the VCard field of contact.
+func (b *Bot) SendContact(recipient int64, contact Contact, opts ...SendOption) (Message, error) {
 	const method = "sendContact"
-	panic("TODO")
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(recipient, 10))
+	params.Set("phone_number", contact.PhoneNumber)
+	params.Set("first_name", contact.FirstName)
+	if contact.LastName != "" {
+		params.Set("last_name", contact.LastName)
+	}
+	if contact.VCard != "" {
+		params.Set("vcard", contact.VCard)
+	}
+
+	mapSendOptions(&params, opts...)
+
+	var r struct {
+		response
+		Message Message `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return Message{}, err
+	}
+
+	if !r.OK {
+		return Message{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Message, nil
+}
+
+// SendDice sends an animated emoji that will display a random value. If emoji
+// is empty, a die is sent.
+func (b *Bot) SendDice(recipient int64, emoji DiceEmoji, opts ...SendOption) (Message, error) {
+	const method = "sendDice"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(recipient, 10))
+	if emoji != "" {
+		params.Set("emoji", string(emoji))
+	}
+
+	mapSendOptions(&params, opts...)
+
+	var r struct {
+		response
+		Message Message `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return Message{}, err
+	}
+
+	if !r.OK {
+		return Message{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Message, nil
 }
 
 // SendChatAction broadcasts type of action to recipient, such as `typing`,
@@ -283,12 +469,29 @@ func (b *Bot) sendContact(recipient int64, contact Contact, opts ...SendOption)
 // and upload the image. Instead of sending a text message along the lines of
 // “Retrieving image, please wait…”, the bot may use SendChatAction with action
 // = UploadingPhoto. The user will see a “sending photo” status for the bot.
-func (b *Bot) SendChatAction(recipient int64, action ChatAction) error {
+//
+// Only WithMessageThreadID, WithThreadOf and WithBusinessConnectionID options
+// apply to chat actions. Other options are ignored.
+func (b *Bot) SendChatAction(recipient int64, action ChatAction, opts ...SendOption) error {
 	const method = "sendChatAction"
 	params := url.Values{}
 	params.Set("chat_id", strconv.FormatInt(recipient, 10))
 	params.Set("action", string(action))
 
+	var o sendOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	if o.messageThreadID != 0 {
+		params.Set("message_thread_id", strconv.FormatInt(o.messageThreadID, 10))
+	}
+
+	if o.businessConnectionID != "" {
+		params.Set("business_connection_id", o.businessConnectionID)
+	}
+
 	var r response
 	err := b.sendCommand(nil, method, params, &r)
 	if err != nil {
@@ -372,18 +575,527 @@ func (b *Bot) deleteChatPhoto(recipient int64) error {
 	panic("TODO")
 }
 
+// BanChatMember bans a user in a group, a supergroup or a channel. In the case
+// of supergroups and channels, the user will not be able to return to the chat
+// on their own using invite links, unless unbanned first. The bot must be an
+// administrator in the chat and must have the appropriate admin rights.
+//
+// If until is zero or more than 366 days from now, the user is banned forever.
+// If revokeMessages is true, all messages from the group of the user will be
+// deleted.
+func (b *Bot) BanChatMember(chatID, userID int64, until time.Time, revokeMessages bool) error {
+	const method = "banChatMember"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+	if !until.IsZero() {
+		params.Set("until_date", strconv.FormatInt(until.Unix(), 10))
+	}
+	if revokeMessages {
+		params.Set("revoke_messages", "true")
+	}
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// UnbanChatMember unbans a previously banned user in a supergroup or channel.
+// The user will not return to the group or channel automatically, but will be
+// able to join via link, etc.
+//
+// By default, this method guarantees that after the call the user is not a
+// member of the chat, but will be able to join it. So if the user is a member
+// of the chat they will also be removed from the chat. If you don't want this,
+// set onlyIfBanned.
+func (b *Bot) UnbanChatMember(chatID, userID int64, onlyIfBanned bool) error {
+	const method = "unbanChatMember"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+	if onlyIfBanned {
+		params.Set("only_if_banned", "true")
+	}
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// RestrictChatMember restricts a user in a supergroup. The bot must be an
+// administrator in the supergroup for this to work and must have the
+// appropriate admin rights. Pass all permissions to lift restrictions from a
+// user.
+//
+// If until is zero or more than 366 days from now, the user is restricted
+// forever.
+func (b *Bot) RestrictChatMember(chatID, userID int64, permissions ChatPermissions, until time.Time) error {
+	const method = "restrictChatMember"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+
+	perms, err := json.Marshal(permissions)
+	if err != nil {
+		return err
+	}
+	params.Set("permissions", string(perms))
+
+	if !until.IsZero() {
+		params.Set("until_date", strconv.FormatInt(until.Unix(), 10))
+	}
+
+	var r response
+	err = b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// PromoteChatMember promotes or demotes a user in a supergroup or a channel.
+// The bot must be an administrator in the chat for this to work and must have
+// the appropriate admin rights. Pass zero value of ChatAdministratorRights to
+// demote a user.
+func (b *Bot) PromoteChatMember(chatID, userID int64, rights ChatAdministratorRights) error {
+	const method = "promoteChatMember"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+	params.Set("is_anonymous", strconv.FormatBool(rights.IsAnonymous))
+	params.Set("can_manage_chat", strconv.FormatBool(rights.CanManageChat))
+	params.Set("can_delete_messages", strconv.FormatBool(rights.CanDeleteMessages))
+	params.Set("can_manage_video_chats", strconv.FormatBool(rights.CanManageVideoChats))
+	params.Set("can_restrict_members", strconv.FormatBool(rights.CanRestrictMembers))
+	params.Set("can_promote_members", strconv.FormatBool(rights.CanPromoteMembers))
+	params.Set("can_change_info", strconv.FormatBool(rights.CanChangeInfo))
+	params.Set("can_invite_users", strconv.FormatBool(rights.CanInviteUsers))
+	params.Set("can_post_stories", strconv.FormatBool(rights.CanPostStories))
+	params.Set("can_edit_stories", strconv.FormatBool(rights.CanEditStories))
+	params.Set("can_delete_stories", strconv.FormatBool(rights.CanDeleteStories))
+	params.Set("can_post_messages", strconv.FormatBool(rights.CanPostMessages))
+	params.Set("can_edit_messages", strconv.FormatBool(rights.CanEditMessages))
+	params.Set("can_pin_messages", strconv.FormatBool(rights.CanPinMessages))
+	params.Set("can_manage_topics", strconv.FormatBool(rights.CanManageTopics))
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// SetChatAdministratorCustomTitle sets a custom title for an administrator in
+// a supergroup promoted by the bot. title can be 0-16 characters long, emoji
+// are not allowed.
+func (b *Bot) SetChatAdministratorCustomTitle(chatID, userID int64, title string) error {
+	const method = "setChatAdministratorCustomTitle"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+	params.Set("custom_title", title)
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// BanChatSenderChat bans a channel chat in a supergroup or a channel. Until
+// the chat is unbanned, the owner of the banned chat won't be able to send
+// messages on behalf of any of their channels. The bot must be an
+// administrator in the supergroup or channel for this to work and must have
+// the appropriate administrator rights.
+func (b *Bot) BanChatSenderChat(chatID, senderChatID int64) error {
+	const method = "banChatSenderChat"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("sender_chat_id", strconv.FormatInt(senderChatID, 10))
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// GetChatMember retrieves information about a member of a chat. The method is
+// only guaranteed to work for other users if the bot is an administrator in
+// the chat.
+func (b *Bot) GetChatMember(chatID, userID int64) (ChatMember, error) {
+	const method = "getChatMember"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+
+	var r struct {
+		response
+		Member json.RawMessage `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.OK {
+		return nil, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return unmarshalChatMember(r.Member)
+}
+
+// GetChatAdministrators retrieves a list of administrators in a chat, which
+// aren't bots.
+func (b *Bot) GetChatAdministrators(chatID int64) ([]ChatMember, error) {
+	const method = "getChatAdministrators"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+
+	var r struct {
+		response
+		Members []json.RawMessage `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.OK {
+		return nil, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	members := make([]ChatMember, 0, len(r.Members))
+	for _, raw := range r.Members {
+		m, err := unmarshalChatMember(raw)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+// GetChatMemberCount retrieves the number of members in a chat.
+func (b *Bot) GetChatMemberCount(chatID int64) (int, error) {
+	const method = "getChatMemberCount"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+
+	var r struct {
+		response
+		Count int `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return 0, err
+	}
+
+	if !r.OK {
+		return 0, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Count, nil
+}
+
+// LeaveChat makes the bot leave a group, supergroup or channel.
+func (b *Bot) LeaveChat(chatID int64) error {
+	const method = "leaveChat"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// PinChatMessage adds a message to the list of pinned messages in a chat. If
+// the chat is not a private chat, the bot must be an administrator in the chat
+// for this to work and must have the appropriate admin rights. If
+// disableNotification is true, chat members are not notified about the new
+// pinned message. Notifications are always disabled in channels and private
+// chats.
+func (b *Bot) PinChatMessage(chatID, messageID int64, disableNotification bool) error {
+	const method = "pinChatMessage"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("message_id", strconv.FormatInt(messageID, 10))
+	if disableNotification {
+		params.Set("disable_notification", "true")
+	}
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// UnpinChatMessage removes a message from the list of pinned messages in a
+// chat. If messageID is 0, the most recent pinned message (by sending date)
+// will be unpinned.
+func (b *Bot) UnpinChatMessage(chatID, messageID int64) error {
+	const method = "unpinChatMessage"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	if messageID != 0 {
+		params.Set("message_id", strconv.FormatInt(messageID, 10))
+	}
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// UnpinAllChatMessages clears the list of pinned messages in a chat.
+func (b *Bot) UnpinAllChatMessages(chatID int64) error {
+	const method = "unpinAllChatMessages"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// ExportChatInviteLink generates a new primary invite link for a chat; any
+// previously generated primary link is revoked. The bot must be an
+// administrator in the chat for this to work and must have the appropriate
+// admin rights.
+func (b *Bot) ExportChatInviteLink(chatID int64) (string, error) {
+	const method = "exportChatInviteLink"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+
+	var r struct {
+		response
+		Link string `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return "", err
+	}
+
+	if !r.OK {
+		return "", fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Link, nil
+}
+
+// CreateChatInviteLink creates an additional invite link for a chat. Name,
+// ExpireDate, MemberLimit and CreatesJoinRequest fields of the given link are
+// used to configure the new link. The bot must be an administrator in the
+// chat for this to work and must have the appropriate admin rights.
+func (b *Bot) CreateChatInviteLink(chatID int64, link ChatInviteLink) (ChatInviteLink, error) {
+	const method = "createChatInviteLink"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	mapChatInviteLink(&params, link)
+
+	var r struct {
+		response
+		Link ChatInviteLink `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return ChatInviteLink{}, err
+	}
+
+	if !r.OK {
+		return ChatInviteLink{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Link, nil
+}
+
+// EditChatInviteLink edits a non-primary invite link created by the bot. The
+// link to edit is identified by the InviteLink field of the given link.
+func (b *Bot) EditChatInviteLink(chatID int64, link ChatInviteLink) (ChatInviteLink, error) {
+	const method = "editChatInviteLink"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("invite_link", link.InviteLink)
+	mapChatInviteLink(&params, link)
+
+	var r struct {
+		response
+		Link ChatInviteLink `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return ChatInviteLink{}, err
+	}
+
+	if !r.OK {
+		return ChatInviteLink{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Link, nil
+}
+
+// RevokeChatInviteLink revokes an invite link created by the bot. If the
+// primary link is revoked, a new link is automatically generated.
+func (b *Bot) RevokeChatInviteLink(chatID int64, inviteLink string) (ChatInviteLink, error) {
+	const method = "revokeChatInviteLink"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("invite_link", inviteLink)
+
+	var r struct {
+		response
+		Link ChatInviteLink `json:"result"`
+	}
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return ChatInviteLink{}, err
+	}
+
+	if !r.OK {
+		return ChatInviteLink{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Link, nil
+}
+
+// ApproveChatJoinRequest approves a chat join request. The bot must be an
+// administrator in the chat for this to work and must have the
+// CanInviteUsers administrator right.
+func (b *Bot) ApproveChatJoinRequest(chatID, userID int64) error {
+	const method = "approveChatJoinRequest"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
+// DeclineChatJoinRequest declines a chat join request. The bot must be an
+// administrator in the chat for this to work and must have the
+// CanInviteUsers administrator right.
+func (b *Bot) DeclineChatJoinRequest(chatID, userID int64) error {
+	const method = "declineChatJoinRequest"
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+
+	var r response
+	err := b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return err
+	}
+
+	if !r.OK {
+		return fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return nil
+}
+
 // sendOptions configure a SendMessage call. sendOptions are set by the
 // SendOption values passed to SendMessage.
 type sendOptions struct {
-	replyTo int64
+	replyParameters ReplyParameters
+
+	allowSendingWithoutReply bool
 
 	parseMode ParseMode
 
-	disableWebPagePreview bool
+	linkPreviewOptions LinkPreviewOptions
 
 	disableNotification bool
 
+	protectContent bool
+
 	replyMarkup ReplyMarkup
+
+	entities []MessageEntity
+
+	messageThreadID int64
+
+	messageEffectID string
+
+	businessConnectionID string
 }
 
 // SendOption configures how we configure the message to be sent.
@@ -400,7 +1112,24 @@ func WithParseMode(mode ParseMode) SendOption {
 // WithReplyTo returns a SendOption which sets the message to be replied to.
 func WithReplyTo(to int64) SendOption {
 	return func(o *sendOptions) {
-		o.replyTo = to
+		o.replyParameters = ReplyParameters{MessageID: to}
+	}
+}
+
+// WithReplyParameters returns a SendOption which sets the message to be
+// replied to, possibly in another chat, along with the quoted part of it. It
+// overrides WithReplyTo.
+func WithReplyParameters(params ReplyParameters) SendOption {
+	return func(o *sendOptions) {
+		o.replyParameters = params
+	}
+}
+
+// WithAllowSendingWithoutReply returns a SendOption which sends the message
+// even if the message to be replied to is not found.
+func WithAllowSendingWithoutReply(allow bool) SendOption {
+	return func(o *sendOptions) {
+		o.allowSendingWithoutReply = allow
 	}
 }
 
@@ -416,7 +1145,15 @@ func WithReplyMarkup(markup ReplyMarkup) SendOption {
 // previews if the message contains a link.
 func WithDisableWebPagePreview(disable bool) SendOption {
 	return func(o *sendOptions) {
-		o.disableWebPagePreview = disable
+		o.linkPreviewOptions.IsDisabled = disable
+	}
+}
+
+// WithLinkPreviewOptions returns a SendOption which configures the link
+// preview generated for the message. It overrides WithDisableWebPagePreview.
+func WithLinkPreviewOptions(options LinkPreviewOptions) SendOption {
+	return func(o *sendOptions) {
+		o.linkPreviewOptions = options
 	}
 }
 
@@ -428,7 +1165,58 @@ func WithDisableNotification(disable bool) SendOption {
 	}
 }
 
-func (b *Bot) getMe() (User, error) {
+// WithEntities returns a SendOption which sets the special entities that
+// appear in the message text or caption. It can be used instead of
+// WithParseMode, see Text.
+func WithEntities(entities []MessageEntity) SendOption {
+	return func(o *sendOptions) {
+		o.entities = entities
+	}
+}
+
+// WithProtectContent returns a SendOption which protects the contents of the
+// sent message from forwarding and saving.
+func WithProtectContent(protect bool) SendOption {
+	return func(o *sendOptions) {
+		o.protectContent = protect
+	}
+}
+
+// WithMessageThreadID returns a SendOption which sends the message to the
+// given message thread, that is the forum topic, of a forum supergroup.
+func WithMessageThreadID(id int64) SendOption {
+	return func(o *sendOptions) {
+		o.messageThreadID = id
+	}
+}
+
+// WithMessageEffectID returns a SendOption which adds the message effect with
+// the given identifier to the message. Effects are available in private chats
+// only.
+func WithMessageEffectID(id string) SendOption {
+	return func(o *sendOptions) {
+		o.messageEffectID = id
+	}
+}
+
+// WithBusinessConnectionID returns a SendOption which sends the message on
+// behalf of the business account with the given connection identifier.
+func WithBusinessConnectionID(id string) SendOption {
+	return func(o *sendOptions) {
+		o.businessConnectionID = id
+	}
+}
+
+// GetMe returns basic information about the bot. The result is cached after
+// the first successful call.
+func (b *Bot) GetMe() (User, error) {
+	b.mu.Lock()
+	me := b.me
+	b.mu.Unlock()
+	if me != nil {
+		return *me, nil
+	}
+
 	var r struct {
 		response
 		User User `json:"result"`
@@ -442,6 +1230,10 @@ func (b *Bot) getMe() (User, error) {
 		return User{}, fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
 	}
 
+	b.mu.Lock()
+	b.me = &r.User
+	b.mu.Unlock()
+
 	return r.User, nil
 }
 
@@ -513,26 +1305,101 @@ func mapSendOptions(m *url.Values, opts ...SendOption) {
 		}
 	}
 
-	if o.replyTo != 0 {
-		m.Set("reply_to_message_id", strconv.FormatInt(o.replyTo, 10))
+	if o.businessConnectionID != "" {
+		m.Set("business_connection_id", o.businessConnectionID)
 	}
 
-	if o.disableWebPagePreview {
-		m.Set("disable_web_page_preview", "true")
+	if o.messageThreadID != 0 {
+		m.Set("message_thread_id", strconv.FormatInt(o.messageThreadID, 10))
+	}
+
+	if o.replyParameters.MessageID != 0 {
+		rp := o.replyParameters
+		if o.allowSendingWithoutReply {
+			rp.AllowSendingWithoutReply = true
+		}
+		params, _ := json.Marshal(rp)
+		m.Set("reply_parameters", string(params))
+	}
+
+	if o.linkPreviewOptions != (LinkPreviewOptions{}) {
+		lpo, _ := json.Marshal(o.linkPreviewOptions)
+		m.Set("link_preview_options", string(lpo))
 	}
 
 	if o.disableNotification {
 		m.Set("disable_notification", "true")
 	}
 
+	if o.protectContent {
+		m.Set("protect_content", "true")
+	}
+
+	if o.messageEffectID != "" {
+		m.Set("message_effect_id", o.messageEffectID)
+	}
+
 	if o.parseMode != ModeNone {
 		m.Set("parse_mode", string(o.parseMode))
 	}
 
-	if o.replyMarkup.Keyboard != nil {
+	if o.replyMarkup.Keyboard != nil || o.replyMarkup.Buttons != nil || o.replyMarkup.InlineKeyboard != nil {
 		kb, _ := json.Marshal(o.replyMarkup)
 		m.Set("reply_markup", string(kb))
 	}
+
+	if len(o.entities) > 0 {
+		entities, _ := json.Marshal(o.entities)
+		m.Set("entities", string(entities))
+	}
+}
+
+// mapCaptionEntities moves the entities set by mapSendOptions to the
+// caption_entities parameter, for methods which send media with a caption.
+func mapCaptionEntities(m *url.Values) {
+	if entities := m.Get("entities"); entities != "" {
+		m.Del("entities")
+		m.Set("caption_entities", entities)
+	}
+}
+
+func mapLocation(m *url.Values, loc Location) {
+	m.Set("latitude", strconv.FormatFloat(loc.Lat, 'f', -1, 64))
+	m.Set("longitude", strconv.FormatFloat(loc.Long, 'f', -1, 64))
+
+	if loc.HorizontalAccuracy != 0 {
+		m.Set("horizontal_accuracy", strconv.FormatFloat(loc.HorizontalAccuracy, 'f', -1, 64))
+	}
+
+	if loc.LivePeriod != 0 {
+		m.Set("live_period", strconv.Itoa(loc.LivePeriod))
+	}
+
+	if loc.Heading != 0 {
+		m.Set("heading", strconv.Itoa(loc.Heading))
+	}
+
+	if loc.ProximityAlertRadius != 0 {
+		m.Set("proximity_alert_radius", strconv.Itoa(loc.ProximityAlertRadius))
+	}
+}
+
+func mapChatInviteLink(m *url.Values, link ChatInviteLink) {
+	if link.Name != "" {
+		m.Set("name", link.Name)
+	}
+
+	if link.ExpireDate != 0 {
+		m.Set("expire_date", strconv.FormatInt(link.ExpireDate, 10))
+	}
+
+	if link.MemberLimit != 0 {
+		m.Set("member_limit", strconv.Itoa(link.MemberLimit))
+	}
+
+	if link.CreatesJoinRequest {
+		m.Set("creates_join_request", "true")
+	}
 }
 
 // response is a common response structure.
@@ -0,0 +1,105 @@
+package telegram
+
+// Text builds a formatted message text. Instead of relying on a parse mode,
+// where user input has to be escaped, it produces the plain text together
+// with the entities describing its formatting. The zero value is an empty
+// text ready to use.
+//
+//	var t telegram.Text
+//	t.Plain("Hello, ").Bold(name).Plain("!")
+//	b.SendMessage(recipient, t.String(), telegram.WithEntities(t.Entities()))
+type Text struct {
+	text     []byte
+	n        int // length of text in UTF-16 code units
+	entities []MessageEntity
+}
+
+// Plain appends s without formatting.
+func (t *Text) Plain(s string) *Text {
+	t.text = append(t.text, s...)
+	t.n += utf16Len(s)
+	return t
+}
+
+// Bold appends s in bold.
+func (t *Text) Bold(s string) *Text {
+	return t.add(s, MessageEntity{Type: EntityBold})
+}
+
+// Italic appends s in italic.
+func (t *Text) Italic(s string) *Text {
+	return t.add(s, MessageEntity{Type: EntityItalic})
+}
+
+// Underline appends s underlined.
+func (t *Text) Underline(s string) *Text {
+	return t.add(s, MessageEntity{Type: EntityUnderline})
+}
+
+// Strikethrough appends s struck through.
+func (t *Text) Strikethrough(s string) *Text {
+	return t.add(s, MessageEntity{Type: EntityStrikethrough})
+}
+
+// Spoiler appends s as a spoiler, which is hidden until the user taps on it.
+func (t *Text) Spoiler(s string) *Text {
+	return t.add(s, MessageEntity{Type: EntitySpoiler})
+}
+
+// Code appends s as inline monowidth code.
+func (t *Text) Code(s string) *Text {
+	return t.add(s, MessageEntity{Type: EntityCode})
+}
+
+// Pre appends s as a monowidth code block. language is the programming
+// language of the code, used for syntax highlighting, and may be empty.
+func (t *Text) Pre(s, language string) *Text {
+	return t.add(s, MessageEntity{Type: EntityPre, Language: language})
+}
+
+// Blockquote appends s as a block quotation.
+func (t *Text) Blockquote(s string) *Text {
+	return t.add(s, MessageEntity{Type: EntityBlockquote})
+}
+
+// Link appends s as a clickable link to url.
+func (t *Text) Link(s, url string) *Text {
+	return t.add(s, MessageEntity{Type: EntityTextLink, URL: url})
+}
+
+// Mention appends s as a mention of the user with the given ID. It works for
+// users without usernames as well.
+func (t *Text) Mention(s string, userID int64) *Text {
+	return t.add(s, MessageEntity{Type: EntityTextMention, User: &User{ID: userID}})
+}
+
+// CustomEmoji appends the custom emoji with the given identifier. emoji is
+// the regular emoji shown in place of the custom emoji where it is not
+// supported.
+func (t *Text) CustomEmoji(emoji, id string) *Text {
+	return t.add(emoji, MessageEntity{Type: EntityCustomEmoji, CustomEmojiID: id})
+}
+
+// String returns the plain text.
+func (t *Text) String() string { return string(t.text) }
+
+// Entities returns the entities of the text, ordered by offset.
+func (t *Text) Entities() []MessageEntity { return t.entities }
+
+// Len returns the length of the text in UTF-16 code units, as measured by
+// Telegram.
+func (t *Text) Len() int { return t.n }
+
+// add appends s and an entity of it, unless s is empty.
+func (t *Text) add(s string, e MessageEntity) *Text {
+	n := utf16Len(s)
+	if n == 0 {
+		return t
+	}
+	e.Offset = t.n
+	e.Length = n
+	t.entities = append(t.entities, e)
+	t.text = append(t.text, s...)
+	t.n += n
+	return t
+}
@@ -0,0 +1,89 @@
+package telegram
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	var txt Text
+	txt.Plain("Hi ").
+		Bold("😀 bold").
+		Plain(", ").
+		Italic("ğ").
+		Underline("").
+		Link("link", "https://example.com").
+		Mention("you", 42).
+		Pre("x := 1", "go").
+		CustomEmoji("👍", "5368324170671202286")
+
+	wantText := "Hi 😀 bold, ğlinkyoux := 1👍"
+	if got := txt.String(); got != wantText {
+		t.Errorf("String() = %q, want %q", got, wantText)
+	}
+	if got, want := txt.Len(), utf16Len(wantText); got != want {
+		t.Errorf("Len() = %v, want %v", got, want)
+	}
+
+	want := []MessageEntity{
+		{Type: EntityBold, Offset: 3, Length: 7},
+		{Type: EntityItalic, Offset: 12, Length: 1},
+		{Type: EntityTextLink, Offset: 13, Length: 4, URL: "https://example.com"},
+		{Type: EntityTextMention, Offset: 17, Length: 3, User: &User{ID: 42}},
+		{Type: EntityPre, Offset: 20, Length: 6, Language: "go"},
+		{Type: EntityCustomEmoji, Offset: 26, Length: 2, CustomEmojiID: "5368324170671202286"},
+	}
+	if got := txt.Entities(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Entities() = %+v, want %+v", got, want)
+	}
+}
+
+func TestTextZeroValue(t *testing.T) {
+	var txt Text
+	if txt.String() != "" || txt.Len() != 0 || txt.Entities() != nil {
+		t.Errorf("zero Text = %q, %v, %v; want empty", txt.String(), txt.Len(), txt.Entities())
+	}
+}
+
+func TestUTF16Len(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"ğüş", 3},
+		{"€", 1},
+		{"😀", 2},
+		{"a😀b", 4},
+		{"👨‍👩‍👧", 8},
+		{"\xff", 1},
+	}
+	for _, tt := range tests {
+		if got := utf16Len(tt.s); got != tt.want {
+			t.Errorf("utf16Len(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestUTF16Slice(t *testing.T) {
+	tests := []struct {
+		s              string
+		offset, length int
+		want           string
+	}{
+		{"hello", 1, 3, "ell"},
+		{"a😀b", 1, 2, "😀"},
+		{"a😀b", 3, 1, "b"},
+		{"a😀b", 0, 10, "a😀b"},
+		{"a😀b", -1, 2, "a"},
+		{"a😀b", 4, 1, ""},
+		{"abc", 1, 0, ""},
+		{"ğü😀ş", 2, 3, "😀ş"},
+	}
+	for _, tt := range tests {
+		if got := utf16Slice(tt.s, tt.offset, tt.length); got != tt.want {
+			t.Errorf("utf16Slice(%q, %v, %v) = %q, want %q", tt.s, tt.offset, tt.length, got, tt.want)
+		}
+	}
+}
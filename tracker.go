@@ -0,0 +1,311 @@
+package telegram
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// GeofenceEventKind is the kind of a GeofenceEvent.
+type GeofenceEventKind int
+
+// Kinds of geofence events
+const (
+	GeofenceEnter GeofenceEventKind = iota
+	GeofenceExit
+)
+
+// String implements fmt.Stringer.
+func (k GeofenceEventKind) String() string {
+	switch k {
+	case GeofenceEnter:
+		return "enter"
+	case GeofenceExit:
+		return "exit"
+	}
+	return "unknown"
+}
+
+// GeofenceEvent reports that a live location entered or exited a geofence.
+type GeofenceEvent struct {
+	// Kind of the event
+	Kind GeofenceEventKind
+
+	// Name of the geofence, as given to Tracker.AddGeofence
+	Fence string
+
+	// Sender of the live location
+	User User
+
+	// Chat and message identifying the live location
+	ChatID    int64
+	MessageID int64
+
+	// The location which triggered the event
+	Location Location
+
+	// Time of the location update
+	Time time.Time
+}
+
+// TrackPoint is a position of a live location at a moment.
+type TrackPoint struct {
+	Location Location
+	Time     time.Time
+}
+
+// LiveTrack is the history of a live location message.
+type LiveTrack struct {
+	// Sender of the live location
+	User User
+
+	// Chat and message identifying the live location
+	ChatID    int64
+	MessageID int64
+
+	// Positions of the live location, oldest first
+	Points []TrackPoint
+}
+
+// Latest returns the most recent position of the track.
+func (t LiveTrack) Latest() TrackPoint {
+	if len(t.Points) == 0 {
+		return TrackPoint{}
+	}
+	return t.Points[len(t.Points)-1]
+}
+
+// Tracker follows live locations shared with the bot. A live location
+// arrives first as a new message, and then as edited messages every time its
+// position changes. Feed both to the tracker: messages from Messages and
+// edited messages from Updates.
+//
+//	t := telegram.NewTracker()
+//	t.AddGeofence("office", telegram.Circle{Center: office, Radius: 100})
+//	go func() {
+//		for msg := range bot.Messages() {
+//			handle(t.Observe(*msg))
+//		}
+//	}()
+//	for u := range bot.Updates() {
+//		handle(t.Observe(u.EditedMessage))
+//	}
+//
+// Tracker is safe for concurrent use.
+type Tracker struct {
+	// MaxPoints is the maximum number of points kept per track. Older points
+	// are dropped first. If zero, all points are kept.
+	MaxPoints int
+
+	mu     sync.Mutex
+	fences map[string]Geofence
+	tracks map[trackKey]*trackState
+	now    func() time.Time
+}
+
+type trackKey struct {
+	chatID    int64
+	messageID int64
+}
+
+type trackState struct {
+	track   LiveTrack
+	inside  map[string]bool // fence name -> whether the last point was inside
+	expires time.Time       // zero if the live location never expires
+}
+
+// NewTracker returns a new Tracker, which keeps up to 1000 points per track.
+func NewTracker() *Tracker {
+	return &Tracker{
+		MaxPoints: 1000,
+		fences:    make(map[string]Geofence),
+		tracks:    make(map[trackKey]*trackState),
+		now:       time.Now,
+	}
+}
+
+// AddGeofence adds a geofence with the given name, replacing any geofence
+// with the same name. Tracks already inside the area will report an enter
+// event on their next update.
+func (t *Tracker) AddGeofence(name string, fence Geofence) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.fences[name] = fence
+	for _, s := range t.tracks {
+		delete(s.inside, name)
+	}
+}
+
+// RemoveGeofence removes the geofence with the given name.
+func (t *Tracker) RemoveGeofence(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.fences, name)
+	for _, s := range t.tracks {
+		delete(s.inside, name)
+	}
+}
+
+// Observe records the position of a live location message and returns the
+// geofence events it triggers, sorted by fence name. Messages without a live
+// location are ignored.
+//
+// When the live location is stopped, Telegram sends a last edit without a
+// live period. The track ends there: exit events are returned for the
+// geofences the track was inside, and the track is dropped. Tracks whose live
+// period has expired by the time of the observed message are dropped as well.
+func (t *Tracker) Observe(m Message) []GeofenceEvent {
+	if m.ID == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	when := m.Time()
+	if m.EditUnixtime != 0 {
+		when = time.Unix(m.EditUnixtime, 0).UTC()
+	}
+	t.prune(when)
+
+	key := trackKey{chatID: m.Chat.ID, messageID: m.ID}
+	s, ok := t.tracks[key]
+	if m.Location.LivePeriod == 0 {
+		if !ok {
+			return nil
+		}
+		delete(t.tracks, key)
+		return s.exitAll(m.Location, when)
+	}
+
+	if !ok {
+		s = &trackState{
+			track: LiveTrack{
+				User:      m.From,
+				ChatID:    m.Chat.ID,
+				MessageID: m.ID,
+			},
+			inside: make(map[string]bool),
+		}
+		t.tracks[key] = s
+	}
+
+	// the live period may be extended by an edit, and it counts from the
+	// time the message was sent.
+	s.expires = time.Time{}
+	if m.Location.LivePeriod != LivePeriodIndefinite {
+		s.expires = m.Time().Add(time.Duration(m.Location.LivePeriod) * time.Second)
+	}
+
+	s.track.Points = append(s.track.Points, TrackPoint{Location: m.Location, Time: when})
+	if t.MaxPoints > 0 && len(s.track.Points) > t.MaxPoints {
+		s.track.Points = s.track.Points[len(s.track.Points)-t.MaxPoints:]
+	}
+
+	var events []GeofenceEvent
+	for name, fence := range t.fences {
+		in := fence.Contains(m.Location)
+		if in == s.inside[name] {
+			continue
+		}
+		s.inside[name] = in
+
+		kind := GeofenceExit
+		if in {
+			kind = GeofenceEnter
+		}
+		events = append(events, s.event(kind, name, m.Location, when))
+	}
+	sortEvents(events)
+	return events
+}
+
+// prune drops the tracks whose live period has expired at now.
+func (t *Tracker) prune(now time.Time) {
+	for key, s := range t.tracks {
+		if !s.expires.IsZero() && now.After(s.expires) {
+			delete(t.tracks, key)
+		}
+	}
+}
+
+// Track returns the track of the live location identified by chatID and
+// messageID. Tracks whose live period has expired are dropped.
+func (t *Tracker) Track(chatID, messageID int64) (LiveTrack, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune(t.now())
+
+	s, ok := t.tracks[trackKey{chatID: chatID, messageID: messageID}]
+	if !ok {
+		return LiveTrack{}, false
+	}
+	return s.copy(), true
+}
+
+// UserTracks returns the tracks of the live locations shared by the user.
+// Tracks whose live period has expired are dropped.
+func (t *Tracker) UserTracks(userID int64) []LiveTrack {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune(t.now())
+
+	var tracks []LiveTrack
+	for _, s := range t.tracks {
+		if s.track.User.ID == userID {
+			tracks = append(tracks, s.copy())
+		}
+	}
+	sort.Slice(tracks, func(i, j int) bool {
+		return tracks[i].Latest().Time.Before(tracks[j].Latest().Time)
+	})
+	return tracks
+}
+
+// Forget stops tracking the live location identified by chatID and
+// messageID and drops its history.
+func (t *Tracker) Forget(chatID, messageID int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.tracks, trackKey{chatID: chatID, messageID: messageID})
+}
+
+func (s *trackState) copy() LiveTrack {
+	track := s.track
+	track.Points = append([]TrackPoint(nil), s.track.Points...)
+	return track
+}
+
+// exitAll returns exit events for all the geofences the track is inside, as
+// the track ends at the given location.
+func (s *trackState) exitAll(loc Location, when time.Time) []GeofenceEvent {
+	var events []GeofenceEvent
+	for name, in := range s.inside {
+		if in {
+			events = append(events, s.event(GeofenceExit, name, loc, when))
+		}
+	}
+	sortEvents(events)
+	return events
+}
+
+func (s *trackState) event(kind GeofenceEventKind, fence string, loc Location, when time.Time) GeofenceEvent {
+	return GeofenceEvent{
+		Kind:      kind,
+		Fence:     fence,
+		User:      s.track.User,
+		ChatID:    s.track.ChatID,
+		MessageID: s.track.MessageID,
+		Location:  loc,
+		Time:      when,
+	}
+}
+
+func sortEvents(events []GeofenceEvent) {
+	sort.Slice(events, func(i, j int) bool { return events[i].Fence < events[j].Fence })
+}
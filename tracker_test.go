@@ -0,0 +1,202 @@
+package telegram
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTrackerObserve(t *testing.T) {
+	office := Location{Lat: 41, Long: 29}
+	away := Location{Lat: 41.01, Long: 29}
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// live returns a live location message sent at t0, edited after the
+	// given number of seconds unless it is 0.
+	live := func(id int64, loc Location, period int, edit int64) Message {
+		loc.LivePeriod = period
+		m := Message{
+			ID:       id,
+			From:     User{ID: 7},
+			Chat:     Chat{ID: 100},
+			Unixtime: t0.Unix(),
+			Location: loc,
+		}
+		if edit != 0 {
+			m.EditUnixtime = t0.Unix() + edit
+		}
+		return m
+	}
+
+	type step struct {
+		msg  Message
+		want []GeofenceEventKind
+	}
+	tests := []struct {
+		name   string
+		steps  []step
+		points int // number of points of track 1 at the end, -1 if dropped
+	}{
+		{
+			name: "enter and exit",
+			steps: []step{
+				{live(1, away, 600, 0), nil},
+				{live(1, office, 600, 10), []GeofenceEventKind{GeofenceEnter}},
+				{live(1, office, 600, 20), nil},
+				{live(1, away, 600, 30), []GeofenceEventKind{GeofenceExit}},
+				{live(1, office, 600, 40), []GeofenceEventKind{GeofenceEnter}},
+			},
+			points: 5,
+		},
+		{
+			name: "stop inside",
+			steps: []step{
+				{live(1, office, 600, 0), []GeofenceEventKind{GeofenceEnter}},
+				{live(1, office, 0, 10), []GeofenceEventKind{GeofenceExit}},
+			},
+			points: -1,
+		},
+		{
+			name: "stop outside",
+			steps: []step{
+				{live(1, away, 600, 0), nil},
+				{live(1, away, 0, 10), nil},
+			},
+			points: -1,
+		},
+		{
+			name: "stop unknown",
+			steps: []step{
+				{live(1, office, 0, 10), nil},
+			},
+			points: -1,
+		},
+		{
+			name: "prune expired",
+			steps: []step{
+				{live(1, office, 60, 0), []GeofenceEventKind{GeofenceEnter}},
+				{live(2, away, 600, 120), nil},
+			},
+			points: -1,
+		},
+		{
+			name: "extended live period",
+			steps: []step{
+				{live(1, office, 60, 0), []GeofenceEventKind{GeofenceEnter}},
+				{live(1, office, 300, 50), nil},
+				{live(2, away, 600, 120), nil},
+				{live(1, office, 300, 200), nil},
+			},
+			points: 3,
+		},
+		{
+			name: "indefinite",
+			steps: []step{
+				{live(1, office, LivePeriodIndefinite, 0), []GeofenceEventKind{GeofenceEnter}},
+				{live(2, away, 600, 100000), nil},
+			},
+			points: 1,
+		},
+		{
+			name: "not live",
+			steps: []step{
+				{Message{}, nil},
+			},
+			points: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := NewTracker()
+			tr.now = func() time.Time { return t0 }
+			tr.AddGeofence("office", Circle{Center: office, Radius: 100})
+
+			for i, st := range tt.steps {
+				var got []GeofenceEventKind
+				for _, e := range tr.Observe(st.msg) {
+					if e.Fence != "office" || e.ChatID != 100 || e.MessageID != st.msg.ID || e.User.ID != 7 {
+						t.Errorf("step %v: unexpected event %+v", i, e)
+					}
+					got = append(got, e.Kind)
+				}
+				if !reflect.DeepEqual(got, st.want) {
+					t.Errorf("step %v: Observe = %v, want %v", i, got, st.want)
+				}
+			}
+
+			track, ok := tr.Track(100, 1)
+			switch {
+			case tt.points < 0 && ok:
+				t.Errorf("Track = %+v, want dropped", track)
+			case tt.points >= 0 && !ok:
+				t.Errorf("Track dropped, want %v points", tt.points)
+			case ok && len(track.Points) != tt.points:
+				t.Errorf("Track has %v points, want %v", len(track.Points), tt.points)
+			}
+		})
+	}
+}
+
+func TestTrackerPruneOnRead(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := t0
+	tr := NewTracker()
+	tr.now = func() time.Time { return now }
+
+	tr.Observe(Message{
+		ID:       1,
+		From:     User{ID: 7},
+		Chat:     Chat{ID: 100},
+		Unixtime: t0.Unix(),
+		Location: Location{Lat: 41, Long: 29, LivePeriod: 60},
+	})
+
+	now = t0.Add(30 * time.Second)
+	if _, ok := tr.Track(100, 1); !ok {
+		t.Errorf("Track dropped before the live period ended")
+	}
+	if tracks := tr.UserTracks(7); len(tracks) != 1 {
+		t.Errorf("UserTracks = %v tracks, want 1", len(tracks))
+	}
+
+	now = t0.Add(61 * time.Second)
+	if _, ok := tr.Track(100, 1); ok {
+		t.Errorf("Track kept after the live period ended")
+	}
+	if tracks := tr.UserTracks(7); len(tracks) != 0 {
+		t.Errorf("UserTracks = %v tracks, want 0", len(tracks))
+	}
+}
+
+func TestTrackerGeofences(t *testing.T) {
+	tr := NewTracker()
+	tr.AddGeofence("b", Circle{Center: Location{Lat: 41, Long: 29}, Radius: 1000})
+	tr.AddGeofence("a", Circle{Center: Location{Lat: 41, Long: 29}, Radius: 100})
+
+	m := Message{
+		ID:       1,
+		Chat:     Chat{ID: 100},
+		Unixtime: time.Now().Unix(),
+		Location: Location{Lat: 41, Long: 29, LivePeriod: 600},
+	}
+	events := tr.Observe(m)
+	if len(events) != 2 || events[0].Fence != "a" || events[1].Fence != "b" {
+		t.Fatalf("Observe = %+v, want enter events for a and b", events)
+	}
+
+	// re-adding a geofence reports an enter event again.
+	tr.AddGeofence("a", Circle{Center: Location{Lat: 41, Long: 29}, Radius: 200})
+	m.EditUnixtime = m.Unixtime + 10
+	events = tr.Observe(m)
+	if len(events) != 1 || events[0].Fence != "a" || events[0].Kind != GeofenceEnter {
+		t.Errorf("Observe = %+v, want enter event for a", events)
+	}
+
+	tr.RemoveGeofence("b")
+	m.Location.LivePeriod = 0
+	m.EditUnixtime += 10
+	events = tr.Observe(m)
+	if len(events) != 1 || events[0].Fence != "a" || events[0].Kind != GeofenceExit {
+		t.Errorf("Observe = %+v, want exit event for a", events)
+	}
+}
@@ -2,6 +2,7 @@ package telegram
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -13,9 +14,10 @@ type ParseMode string
 
 // Parse modes
 const (
-	ModeNone     ParseMode = ""
-	ModeMarkdown ParseMode = "Markdown"
-	ModeHTML     ParseMode = "HTML"
+	ModeNone       ParseMode = ""
+	ModeMarkdown   ParseMode = "Markdown"
+	ModeMarkdownV2 ParseMode = "MarkdownV2"
+	ModeHTML       ParseMode = "HTML"
 )
 
 // ChatAction represents bot activity.
@@ -48,6 +50,9 @@ type User struct {
 
 	// IETF language tag of the user's language
 	LanguageCode string `json:"language_code"`
+
+	// True, if this user is a bot
+	IsBot bool `json:"is_bot,omitempty"`
 }
 
 // Chat represents a Telegram chat.
@@ -72,11 +77,517 @@ type Chat struct {
 
 	// Last name of the other party in a private chat
 	LastName string `json:"last_name"`
+
+	// True, if the supergroup chat is a forum, that is it has topics enabled
+	IsForum bool `json:"is_forum,omitempty"`
 }
 
 // IsGroupChat reports whether the message is originally sent from a chat group.
 func (c Chat) IsGroupChat() bool { return c.Type == "group" }
 
+// ChatPermissions describes actions that a non-administrator user is allowed
+// to take in a chat.
+type ChatPermissions struct {
+	// True, if the user is allowed to send text messages, contacts, giveaways,
+	// giveaway winners, invoices, locations and venues
+	CanSendMessages bool `json:"can_send_messages"`
+
+	// True, if the user is allowed to send audios
+	CanSendAudios bool `json:"can_send_audios"`
+
+	// True, if the user is allowed to send documents
+	CanSendDocuments bool `json:"can_send_documents"`
+
+	// True, if the user is allowed to send photos
+	CanSendPhotos bool `json:"can_send_photos"`
+
+	// True, if the user is allowed to send videos
+	CanSendVideos bool `json:"can_send_videos"`
+
+	// True, if the user is allowed to send video notes
+	CanSendVideoNotes bool `json:"can_send_video_notes"`
+
+	// True, if the user is allowed to send voice notes
+	CanSendVoiceNotes bool `json:"can_send_voice_notes"`
+
+	// True, if the user is allowed to send polls
+	CanSendPolls bool `json:"can_send_polls"`
+
+	// True, if the user is allowed to send animations, games, stickers and use
+	// inline bots
+	CanSendOtherMessages bool `json:"can_send_other_messages"`
+
+	// True, if the user is allowed to add web page previews to their messages
+	CanAddWebPagePreviews bool `json:"can_add_web_page_previews"`
+
+	// True, if the user is allowed to change the chat title, photo and other
+	// settings. Ignored in public supergroups
+	CanChangeInfo bool `json:"can_change_info"`
+
+	// True, if the user is allowed to invite new users to the chat
+	CanInviteUsers bool `json:"can_invite_users"`
+
+	// True, if the user is allowed to pin messages. Ignored in public
+	// supergroups
+	CanPinMessages bool `json:"can_pin_messages"`
+
+	// True, if the user is allowed to create forum topics
+	CanManageTopics bool `json:"can_manage_topics"`
+}
+
+// ChatAdministratorRights represents the rights of an administrator in a chat.
+type ChatAdministratorRights struct {
+	// True, if the user's presence in the chat is hidden
+	IsAnonymous bool `json:"is_anonymous"`
+
+	// True, if the administrator can access the chat event log, get boost list,
+	// see hidden supergroup and channel members, report spam messages and
+	// ignore slow mode
+	CanManageChat bool `json:"can_manage_chat"`
+
+	// True, if the administrator can delete messages of other users
+	CanDeleteMessages bool `json:"can_delete_messages"`
+
+	// True, if the administrator can manage video chats
+	CanManageVideoChats bool `json:"can_manage_video_chats"`
+
+	// True, if the administrator can restrict, ban or unban chat members, or
+	// access supergroup statistics
+	CanRestrictMembers bool `json:"can_restrict_members"`
+
+	// True, if the administrator can add new administrators with a subset of
+	// their own privileges or demote administrators that they have promoted
+	CanPromoteMembers bool `json:"can_promote_members"`
+
+	// True, if the user is allowed to change the chat title, photo and other
+	// settings
+	CanChangeInfo bool `json:"can_change_info"`
+
+	// True, if the user is allowed to invite new users to the chat
+	CanInviteUsers bool `json:"can_invite_users"`
+
+	// True, if the administrator can post stories to the chat
+	CanPostStories bool `json:"can_post_stories"`
+
+	// True, if the administrator can edit stories posted by other users
+	CanEditStories bool `json:"can_edit_stories"`
+
+	// True, if the administrator can delete stories posted by other users
+	CanDeleteStories bool `json:"can_delete_stories"`
+
+	// True, if the administrator can post messages in the channel; channels
+	// only
+	CanPostMessages bool `json:"can_post_messages,omitempty"`
+
+	// True, if the administrator can edit messages of other users and can pin
+	// messages; channels only
+	CanEditMessages bool `json:"can_edit_messages,omitempty"`
+
+	// True, if the user is allowed to pin messages; groups and supergroups only
+	CanPinMessages bool `json:"can_pin_messages,omitempty"`
+
+	// True, if the user is allowed to create, rename, close, and reopen forum
+	// topics; supergroups only
+	CanManageTopics bool `json:"can_manage_topics,omitempty"`
+}
+
+// ChatInviteLink represents an invite link for a chat.
+type ChatInviteLink struct {
+	// The invite link. If the link was created by another chat administrator,
+	// then the second part of the link will be replaced with “…”
+	InviteLink string `json:"invite_link"`
+
+	// Creator of the link
+	Creator User `json:"creator"`
+
+	// True, if users joining the chat via the link need to be approved by chat
+	// administrators
+	CreatesJoinRequest bool `json:"creates_join_request"`
+
+	// True, if the link is primary
+	IsPrimary bool `json:"is_primary"`
+
+	// True, if the link is revoked
+	IsRevoked bool `json:"is_revoked"`
+
+	// Invite link name
+	Name string `json:"name,omitempty"`
+
+	// Point in time (Unix timestamp) when the link will expire or has been
+	// expired
+	ExpireDate int64 `json:"expire_date,omitempty"`
+
+	// The maximum number of users that can be members of the chat
+	// simultaneously after joining the chat via this invite link; 1-99999
+	MemberLimit int `json:"member_limit,omitempty"`
+
+	// Number of pending join requests created using this link
+	PendingJoinRequestCount int `json:"pending_join_request_count,omitempty"`
+}
+
+// Expires returns the moment the link expires in UTC time. It returns the
+// zero time if the link never expires.
+func (l ChatInviteLink) Expires() time.Time {
+	if l.ExpireDate == 0 {
+		return time.Time{}
+	}
+	return time.Unix(l.ExpireDate, 0).UTC()
+}
+
+// ChatJoinRequest represents a join request sent to a chat.
+//
+// The bot can message the applicant via UserChatID for up to 5 minutes after
+// the request is sent, until it is processed with ApproveChatJoinRequest or
+// DeclineChatJoinRequest.
+type ChatJoinRequest struct {
+	// Chat to which the request was sent
+	Chat Chat `json:"chat"`
+
+	// User that sent the join request
+	From User `json:"from"`
+
+	// Identifier of a private chat with the user who sent the join request
+	UserChatID int64 `json:"user_chat_id"`
+
+	// Date the request was sent in Unix time
+	Unixtime int64 `json:"date"`
+
+	// Bio of the user
+	Bio string `json:"bio,omitempty"`
+
+	// Chat invite link that was used by the user to send the join request
+	InviteLink *ChatInviteLink `json:"invite_link,omitempty"`
+}
+
+// Time returns the moment of join request in UTC time.
+func (r ChatJoinRequest) Time() time.Time {
+	return time.Unix(r.Unixtime, 0).UTC()
+}
+
+// ChatMemberStatus is the status of a member in a chat.
+type ChatMemberStatus string
+
+// Chat member statuses
+const (
+	StatusOwner         ChatMemberStatus = "creator"
+	StatusAdministrator ChatMemberStatus = "administrator"
+	StatusMember        ChatMemberStatus = "member"
+	StatusRestricted    ChatMemberStatus = "restricted"
+	StatusLeft          ChatMemberStatus = "left"
+	StatusBanned        ChatMemberStatus = "kicked"
+)
+
+// IsAdministrator reports whether the status belongs to the owner or an
+// administrator of the chat.
+func (s ChatMemberStatus) IsAdministrator() bool {
+	return s == StatusOwner || s == StatusAdministrator
+}
+
+// ChatMember contains information about one member of a chat. Its dynamic
+// type is one of ChatMemberOwner, ChatMemberAdministrator, ChatMemberMember,
+// ChatMemberRestricted, ChatMemberLeft or ChatMemberBanned, or
+// ChatMemberUnknown for statuses introduced to Telegram later.
+type ChatMember interface {
+	// Status returns the member's status in the chat.
+	Status() ChatMemberStatus
+
+	// Member returns information about the user.
+	Member() User
+}
+
+// ChatMemberOwner represents a chat member that owns the chat and has all
+// administrator privileges.
+type ChatMemberOwner struct {
+	// Information about the user
+	User User `json:"user"`
+
+	// True, if the user's presence in the chat is hidden
+	IsAnonymous bool `json:"is_anonymous"`
+
+	// Custom title for this user
+	CustomTitle string `json:"custom_title,omitempty"`
+}
+
+// Status implements ChatMember.
+func (m ChatMemberOwner) Status() ChatMemberStatus { return StatusOwner }
+
+// Member implements ChatMember.
+func (m ChatMemberOwner) Member() User { return m.User }
+
+// ChatMemberAdministrator represents a chat member that has some additional
+// privileges.
+type ChatMemberAdministrator struct {
+	// Information about the user
+	User User `json:"user"`
+
+	// True, if the bot is allowed to edit administrator privileges of that user
+	CanBeEdited bool `json:"can_be_edited"`
+
+	ChatAdministratorRights
+
+	// Custom title for this user
+	CustomTitle string `json:"custom_title,omitempty"`
+}
+
+// Status implements ChatMember.
+func (m ChatMemberAdministrator) Status() ChatMemberStatus { return StatusAdministrator }
+
+// Member implements ChatMember.
+func (m ChatMemberAdministrator) Member() User { return m.User }
+
+// ChatMemberMember represents a chat member that has no additional privileges
+// or restrictions.
+type ChatMemberMember struct {
+	// Information about the user
+	User User `json:"user"`
+
+	// Date when the user's subscription will expire in Unix time
+	UntilDate int64 `json:"until_date,omitempty"`
+}
+
+// Status implements ChatMember.
+func (m ChatMemberMember) Status() ChatMemberStatus { return StatusMember }
+
+// Member implements ChatMember.
+func (m ChatMemberMember) Member() User { return m.User }
+
+// ChatMemberRestricted represents a chat member that is under certain
+// restrictions in the chat. Supergroups only.
+type ChatMemberRestricted struct {
+	// Information about the user
+	User User `json:"user"`
+
+	// True, if the user is a member of the chat at the moment of the request
+	IsMember bool `json:"is_member"`
+
+	ChatPermissions
+
+	// Date when restrictions will be lifted for this user in Unix time. If 0,
+	// then the user is restricted forever
+	UntilDate int64 `json:"until_date"`
+}
+
+// Status implements ChatMember.
+func (m ChatMemberRestricted) Status() ChatMemberStatus { return StatusRestricted }
+
+// Member implements ChatMember.
+func (m ChatMemberRestricted) Member() User { return m.User }
+
+// ChatMemberLeft represents a chat member that isn't currently a member of the
+// chat, but may join it themselves.
+type ChatMemberLeft struct {
+	// Information about the user
+	User User `json:"user"`
+}
+
+// Status implements ChatMember.
+func (m ChatMemberLeft) Status() ChatMemberStatus { return StatusLeft }
+
+// Member implements ChatMember.
+func (m ChatMemberLeft) Member() User { return m.User }
+
+// ChatMemberBanned represents a chat member that was banned in the chat and
+// can't return to the chat or view chat messages.
+type ChatMemberBanned struct {
+	// Information about the user
+	User User `json:"user"`
+
+	// Date when restrictions will be lifted for this user in Unix time. If 0,
+	// then the user is banned forever
+	UntilDate int64 `json:"until_date"`
+}
+
+// Status implements ChatMember.
+func (m ChatMemberBanned) Status() ChatMemberStatus { return StatusBanned }
+
+// Member implements ChatMember.
+func (m ChatMemberBanned) Member() User { return m.User }
+
+// ChatMemberUnknown represents a chat member with a status which is not known
+// to this package. The raw JSON object is kept, so that it can be decoded by
+// the caller.
+type ChatMemberUnknown struct {
+	// Status of the member in the chat
+	MemberStatus ChatMemberStatus `json:"status"`
+
+	// Information about the user
+	User User `json:"user"`
+
+	// The chat member object as received
+	Raw json.RawMessage `json:"-"`
+}
+
+// Status implements ChatMember.
+func (m ChatMemberUnknown) Status() ChatMemberStatus { return m.MemberStatus }
+
+// Member implements ChatMember.
+func (m ChatMemberUnknown) Member() User { return m.User }
+
+// ChatMemberUpdated represents changes in the status of a chat member.
+type ChatMemberUpdated struct {
+	// Chat the user belongs to
+	Chat Chat `json:"chat"`
+
+	// Performer of the action, which resulted in the change
+	From User `json:"from"`
+
+	// Date the change was done in Unix time
+	Unixtime int64 `json:"date"`
+
+	// Previous information about the chat member
+	OldChatMember ChatMember `json:"-"`
+
+	// New information about the chat member
+	NewChatMember ChatMember `json:"-"`
+
+	// Chat invite link, which was used by the user to join the chat; for
+	// joining by invite link events only
+	InviteLink *ChatInviteLink `json:"invite_link,omitempty"`
+
+	// True, if the user joined the chat after sending a direct join request
+	// without using an invite link and being approved by an administrator
+	ViaJoinRequest bool `json:"via_join_request,omitempty"`
+
+	// True, if the user joined the chat via a chat folder invite link
+	ViaChatFolderInviteLink bool `json:"via_chat_folder_invite_link,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Old and new chat members are
+// decoded into their concrete types.
+func (u *ChatMemberUpdated) UnmarshalJSON(data []byte) error {
+	type update ChatMemberUpdated
+	var v struct {
+		update
+		Old json.RawMessage `json:"old_chat_member"`
+		New json.RawMessage `json:"new_chat_member"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*u = ChatMemberUpdated(v.update)
+
+	var err error
+	if len(v.Old) > 0 {
+		u.OldChatMember, err = unmarshalChatMember(v.Old)
+		if err != nil {
+			return err
+		}
+	}
+	if len(v.New) > 0 {
+		u.NewChatMember, err = unmarshalChatMember(v.New)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Time returns the moment of the change in UTC time.
+func (u ChatMemberUpdated) Time() time.Time {
+	return time.Unix(u.Unixtime, 0).UTC()
+}
+
+// Joined reports whether the user became a member of the chat.
+func (u ChatMemberUpdated) Joined() bool {
+	return !isChatMember(u.OldChatMember) && isChatMember(u.NewChatMember)
+}
+
+// Left reports whether the user is no longer a member of the chat, either by
+// leaving or by being removed from it.
+func (u ChatMemberUpdated) Left() bool {
+	return isChatMember(u.OldChatMember) && !isChatMember(u.NewChatMember)
+}
+
+// BotBlocked reports whether the bot was blocked by the user. It is only
+// meaningful for my_chat_member updates.
+func (u ChatMemberUpdated) BotBlocked() bool {
+	return u.Chat.Type == "private" &&
+		u.NewChatMember != nil &&
+		u.NewChatMember.Status() == StatusBanned
+}
+
+// BotUnblocked reports whether the bot was unblocked by the user. It is only
+// meaningful for my_chat_member updates.
+func (u ChatMemberUpdated) BotUnblocked() bool {
+	return u.Chat.Type == "private" &&
+		u.OldChatMember != nil &&
+		u.OldChatMember.Status() == StatusBanned &&
+		isChatMember(u.NewChatMember)
+}
+
+// BotAddedToGroup reports whether the bot was added to a group, a supergroup
+// or a channel. It is only meaningful for my_chat_member updates.
+func (u ChatMemberUpdated) BotAddedToGroup() bool {
+	return u.Chat.Type != "private" && u.Joined()
+}
+
+// BotRemovedFromGroup reports whether the bot left or was removed from a
+// group, a supergroup or a channel. It is only meaningful for my_chat_member
+// updates.
+func (u ChatMemberUpdated) BotRemovedFromGroup() bool {
+	return u.Chat.Type != "private" && u.Left()
+}
+
+// isChatMember reports whether m is currently a member of the chat.
+func isChatMember(m ChatMember) bool {
+	switch m := m.(type) {
+	case ChatMemberOwner, ChatMemberAdministrator, ChatMemberMember:
+		return true
+	case ChatMemberRestricted:
+		return m.IsMember
+	}
+	return false
+}
+
+// unmarshalChatMember decodes a ChatMember into its concrete type based on
+// its status field.
+func unmarshalChatMember(data []byte) (ChatMember, error) {
+	var s struct {
+		Status ChatMemberStatus `json:"status"`
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	var m ChatMember
+	var err error
+	switch s.Status {
+	case StatusOwner:
+		var v ChatMemberOwner
+		err = json.Unmarshal(data, &v)
+		m = v
+	case StatusAdministrator:
+		var v ChatMemberAdministrator
+		err = json.Unmarshal(data, &v)
+		m = v
+	case StatusMember:
+		var v ChatMemberMember
+		err = json.Unmarshal(data, &v)
+		m = v
+	case StatusRestricted:
+		var v ChatMemberRestricted
+		err = json.Unmarshal(data, &v)
+		m = v
+	case StatusLeft:
+		var v ChatMemberLeft
+		err = json.Unmarshal(data, &v)
+		m = v
+	case StatusBanned:
+		var v ChatMemberBanned
+		err = json.Unmarshal(data, &v)
+		m = v
+	default:
+		v := ChatMemberUnknown{Raw: append(json.RawMessage(nil), data...)}
+		err = json.Unmarshal(data, &v)
+		m = v
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 type Update struct {
 	// The update‘s unique identifier. Update identifiers start from a certain
 	// positive number and increase sequentially. This ID becomes especially handy
@@ -89,6 +600,55 @@ type Update struct {
 
 	// New version of a message that is known to the bot and was edited
 	EditedMessage Message `json:"edited_message,omitempty"`
+
+	// A request to join the chat has been sent. The bot must have the
+	// CanInviteUsers administrator right in the chat to receive these updates
+	ChatJoinRequest *ChatJoinRequest `json:"chat_join_request,omitempty"`
+
+	// The bot's chat member status was updated in a chat. For private chats,
+	// this update is received only when the bot is blocked or unblocked by
+	// the user
+	MyChatMember *ChatMemberUpdated `json:"my_chat_member,omitempty"`
+
+	// A chat member's status was updated in a chat. The bot must be an
+	// administrator in the chat and must explicitly specify “chat_member” in
+	// the list of allowed updates to receive these updates
+	ChatMember *ChatMemberUpdated `json:"chat_member,omitempty"`
+
+	// New incoming inline query
+	InlineQuery *InlineQuery `json:"inline_query,omitempty"`
+
+	// The result of an inline query that was chosen by a user and sent to
+	// their chat partner
+	ChosenInlineResult *ChosenInlineResult `json:"chosen_inline_result,omitempty"`
+
+	// New incoming shipping query. Only for invoices with flexible price
+	ShippingQuery *ShippingQuery `json:"shipping_query,omitempty"`
+
+	// New incoming pre-checkout query. Contains full information about
+	// checkout
+	PreCheckoutQuery *PreCheckoutQuery `json:"pre_checkout_query,omitempty"`
+
+	// New poll state. Bots receive only updates about manually stopped polls
+	// and polls, which are sent by the bot
+	Poll *Poll `json:"poll,omitempty"`
+
+	// A user changed their answer in a non-anonymous poll. Bots receive new
+	// votes only in polls that were sent by the bot itself
+	PollAnswer *PollAnswer `json:"poll_answer,omitempty"`
+
+	// New incoming callback query
+	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"`
+
+	// A reaction to a message was changed by a user. The update isn't
+	// received unless "message_reaction" is passed to SetWebhook in allowed
+	// updates
+	MessageReaction *MessageReactionUpdated `json:"message_reaction,omitempty"`
+
+	// Reactions to a message with anonymous reactions were changed. The
+	// update isn't received unless "message_reaction_count" is passed to
+	// SetWebhook in allowed updates
+	MessageReactionCount *MessageReactionCountUpdated `json:"message_reaction_count,omitempty"`
 }
 
 // Message represents a message to be sent.
@@ -96,12 +656,22 @@ type Message struct {
 	// Unique message identifier
 	ID int64 `json:"message_id"`
 
+	// Unique identifier of the message thread or the forum topic the message
+	// belongs to, for supergroups only
+	MessageThreadID int64 `json:"message_thread_id,omitempty"`
+
+	// True, if the message is sent to a forum topic
+	IsTopicMessage bool `json:"is_topic_message,omitempty"`
+
 	// Sender (optional. can be empty for messages sent to channel)
 	From User `json:"from,omitempty"`
 
 	// Date is when the message was sent in Unix time
 	Unixtime int64 `json:"date"`
 
+	// Date the message was last edited in Unix time
+	EditUnixtime int64 `json:"edit_date,omitempty"`
+
 	// Conversation the message belongs to — user in case of a private chat,
 	// group in case of a group chat
 	Chat Chat `json:"chat"`
@@ -155,9 +725,16 @@ type Message struct {
 	// Caption for the document, photo or video, 0-200 characters
 	Caption string `json:"caption,omitempty"`
 
+	// For messages with a caption, special entities like usernames, URLs, bot
+	// commands, etc. that appear in the caption
+	CaptionEntities []MessageEntity `json:"caption_entities,omitempty"`
+
 	// Message is a shared contact, information about the contact
 	Contact Contact `json:"contact,omitempty"`
 
+	// Message is a dice with random value
+	Dice *Dice `json:"dice,omitempty"`
+
 	// Message is a shared location, information about the location
 	Location Location `json:"location,omitempty"`
 
@@ -183,6 +760,40 @@ type Message struct {
 
 	// Informs that the group has been created
 	GroupChatCreated bool `json:"group_chat_created,omitempty"`
+
+	// Message is a native poll, information about the poll
+	Poll *Poll `json:"poll,omitempty"`
+
+	// Message is a game, information about the game
+	Game *Game `json:"game,omitempty"`
+
+	// Message is an invoice for a payment, information about the invoice
+	Invoice *Invoice `json:"invoice,omitempty"`
+
+	// Message is a service message about a successful payment, information
+	// about the payment
+	SuccessfulPayment *SuccessfulPayment `json:"successful_payment,omitempty"`
+
+	// Service message: data sent by a Web App
+	WebAppData *WebAppData `json:"web_app_data,omitempty"`
+
+	// Service message: forum topic created
+	ForumTopicCreated *ForumTopicCreated `json:"forum_topic_created,omitempty"`
+
+	// Service message: forum topic edited
+	ForumTopicEdited *ForumTopicEdited `json:"forum_topic_edited,omitempty"`
+
+	// Service message: forum topic closed
+	ForumTopicClosed *ForumTopicClosed `json:"forum_topic_closed,omitempty"`
+
+	// Service message: forum topic reopened
+	ForumTopicReopened *ForumTopicReopened `json:"forum_topic_reopened,omitempty"`
+
+	// Service message: the General forum topic hidden
+	GeneralForumTopicHidden *GeneralForumTopicHidden `json:"general_forum_topic_hidden,omitempty"`
+
+	// Service message: the General forum topic unhidden
+	GeneralForumTopicUnhidden *GeneralForumTopicUnhidden `json:"general_forum_topic_unhidden,omitempty"`
 }
 
 // String returns a human-readable representation of Message.
@@ -255,6 +866,14 @@ func (m Message) IsService() bool {
 		return true
 	case m.ChatPhotoDeleted:
 		return true
+	case m.SuccessfulPayment != nil, m.WebAppData != nil:
+		return true
+	case m.ForumTopicCreated != nil, m.ForumTopicEdited != nil:
+		return true
+	case m.ForumTopicClosed != nil, m.ForumTopicReopened != nil:
+		return true
+	case m.GeneralForumTopicHidden != nil, m.GeneralForumTopicUnhidden != nil:
+		return true
 	}
 	return false
 }
@@ -340,17 +959,79 @@ type Document struct {
 type Sticker struct {
 	File
 
+	// Type of the sticker. The type of the sticker is independent from its
+	// format, which is determined by the fields IsAnimated and IsVideo
+	Type StickerType `json:"type"`
+
 	// Sticker width
 	Width int `json:"width"`
 
 	// Sticker height
 	Height int `json:"height"`
 
+	// True, if the sticker is animated
+	IsAnimated bool `json:"is_animated,omitempty"`
+
+	// True, if the sticker is a video sticker
+	IsVideo bool `json:"is_video,omitempty"`
+
 	// Sticker thumbnail in .webp or .jpg format
-	Thumbnail Photo `json:"thumb,omitempty"`
+	Thumbnail Photo `json:"thumbnail,omitempty"`
 
 	// Emoji associated with the sticker
 	Emoji string `json:"emoji,omitempty"`
+
+	// Name of the sticker set to which the sticker belongs
+	SetName string `json:"set_name,omitempty"`
+
+	// For premium regular stickers, premium animation for the sticker
+	PremiumAnimation *File `json:"premium_animation,omitempty"`
+
+	// For mask stickers, the position where the mask should be placed
+	MaskPosition *MaskPosition `json:"mask_position,omitempty"`
+
+	// For custom emoji stickers, unique identifier of the custom emoji
+	CustomEmojiID string `json:"custom_emoji_id,omitempty"`
+
+	// True, if the sticker must be repainted to a text color in messages, the
+	// color of the Telegram Premium badge in emoji status, white color on chat
+	// photos, or another appropriate color in other places
+	NeedsRepainting bool `json:"needs_repainting,omitempty"`
+}
+
+// Format returns the format of the sticker.
+func (s Sticker) Format() StickerFormat {
+	switch {
+	case s.IsAnimated:
+		return StickerAnimated
+	case s.IsVideo:
+		return StickerVideo
+	}
+	return StickerStatic
+}
+
+// Animation represents an animation file, a GIF or H.264/MPEG-4 AVC video
+// without sound.
+type Animation struct {
+	File
+
+	// Video width as defined by sender
+	Width int `json:"width"`
+
+	// Video height as defined by sender
+	Height int `json:"height"`
+
+	// Duration of the video in seconds as defined by sender
+	Duration int `json:"duration"`
+
+	// Animation thumbnail as defined by sender
+	Thumbnail Photo `json:"thumbnail,omitempty"`
+
+	// Original animation filename as defined by sender
+	Filename string `json:"file_name,omitempty"`
+
+	// MIME type of the file as defined by sender
+	MimeType string `json:"mime_type,omitempty"`
 }
 
 // Video represents a video file.
@@ -413,6 +1094,33 @@ type Contact struct {
 
 	// Contact's user identifier in Telegram
 	UserID int64 `json:"user_id,omitempty"`
+
+	// Additional data about the contact in the form of a vCard. See
+	// ParseVCard and VCard.String
+	VCard string `json:"vcard,omitempty"`
+}
+
+// DiceEmoji is the emoji on which a dice throw animation is based.
+type DiceEmoji string
+
+// Kinds of dice
+const (
+	DiceDie         DiceEmoji = "🎲"
+	DiceDarts       DiceEmoji = "🎯"
+	DiceBasketball  DiceEmoji = "🏀"
+	DiceFootball    DiceEmoji = "⚽"
+	DiceBowling     DiceEmoji = "🎳"
+	DiceSlotMachine DiceEmoji = "🎰"
+)
+
+// Dice represents an animated emoji that displays a random value.
+type Dice struct {
+	// Emoji on which the dice throw animation is based
+	Emoji DiceEmoji `json:"emoji"`
+
+	// Value of the dice, 1-6 for DiceDie, DiceDarts and DiceBowling, 1-5 for
+	// DiceBasketball and DiceFootball, 1-64 for DiceSlotMachine
+	Value int `json:"value"`
 }
 
 // Location represents a point on the map.
@@ -422,8 +1130,28 @@ type Location struct {
 
 	// Latitude as defined by sender
 	Lat float64 `json:"latitude"`
+
+	// The radius of uncertainty for the location, measured in meters; 0-1500
+	HorizontalAccuracy float64 `json:"horizontal_accuracy,omitempty"`
+
+	// Time relative to the message sending date, during which the location
+	// can be updated; in seconds. For live locations only. Use
+	// LivePeriodIndefinite for live locations that can be edited indefinitely
+	LivePeriod int `json:"live_period,omitempty"`
+
+	// The direction in which user is moving, in degrees; 1-360. For live
+	// locations only
+	Heading int `json:"heading,omitempty"`
+
+	// The maximum distance for proximity alerts about approaching another chat
+	// member, in meters. For live locations only
+	ProximityAlertRadius int `json:"proximity_alert_radius,omitempty"`
 }
 
+// LivePeriodIndefinite is the live period of a live location which can be
+// edited indefinitely.
+const LivePeriodIndefinite = 0x7FFFFFFF
+
 // Venue represents a venue
 type Venue struct {
 	// Venue location
@@ -445,11 +1173,11 @@ type MessageEntity struct {
 	// Type of the entity. Can be mention (@username), hashtag, bot_command, url,
 	// email, bold (bold text), italic (italic text), code (monowidth string), pre
 	// (monowidth block), text_link (for clickable text URLs), text_mention (for
-	// users without usernames)
+	// users without usernames) etc. See the Entity constants
 	Type string `json:"type"`
 
-	// Offset in UTF-16 code units to the start of the entity
-	// TODO(ig):
+	// Offset in UTF-16 code units to the start of the entity. Use
+	// Message.EntityText to extract the text of the entity
 	Offset int `json:"offset"`
 
 	// Length of the entity in UTF-16 code units
@@ -459,7 +1187,13 @@ type MessageEntity struct {
 	URL string `json:"url,omitempty"`
 
 	// For “text_mention” only, the mentioned user
-	User User `json:"user,omitempty"`
+	User *User `json:"user,omitempty"`
+
+	// For “pre” only, the programming language of the entity text
+	Language string `json:"language,omitempty"`
+
+	// For “custom_emoji” only, unique identifier of the custom emoji
+	CustomEmojiID string `json:"custom_emoji_id,omitempty"`
 }
 
 // ReplyMarkup represents a custom keyboard with reply options.
@@ -467,6 +1201,15 @@ type ReplyMarkup struct {
 	// Array of button rows, each represented by an strings
 	Keyboard [][]string `json:"keyboard"`
 
+	// Array of button rows, each represented by an array of KeyboardButton.
+	// If set, it is used instead of Keyboard
+	Buttons [][]KeyboardButton `json:"-"`
+
+	// Array of button rows of an inline keyboard, which appears right next to
+	// the message it belongs to. If set, the markup is an inline keyboard and
+	// the other fields are ignored
+	InlineKeyboard [][]InlineKeyboardButton `json:"-"`
+
 	// Optional. Requests clients to resize the keyboard vertically for optimal
 	// fit (e.g., make the keyboard smaller if there are just two rows of
 	// buttons).
@@ -485,10 +1228,78 @@ type ReplyMarkup struct {
 	Selective bool `json:"selective,omitempty"`
 }
 
+// MarshalJSON implements json.Marshaler.
+func (m ReplyMarkup) MarshalJSON() ([]byte, error) {
+	type markup ReplyMarkup
+	if m.InlineKeyboard != nil {
+		return json.Marshal(struct {
+			InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
+		}{m.InlineKeyboard})
+	}
+	if m.Buttons == nil {
+		return json.Marshal(markup(m))
+	}
+	return json.Marshal(struct {
+		markup
+		Keyboard [][]KeyboardButton `json:"keyboard"`
+	}{markup(m), m.Buttons})
+}
+
+// ReplyParameters describes the message to be replied to.
+type ReplyParameters struct {
+	// Identifier of the message that will be replied to
+	MessageID int64 `json:"message_id"`
+
+	// Optional. If the message to be replied to is from a different chat,
+	// unique identifier for the chat
+	ChatID int64 `json:"chat_id,omitempty"`
+
+	// Optional. Pass true if the message should be sent even if the specified
+	// message to be replied to is not found
+	AllowSendingWithoutReply bool `json:"allow_sending_without_reply,omitempty"`
+
+	// Optional. Quoted part of the message to be replied to; 0-1024 characters
+	// after entities parsing. The quote must be an exact substring of the
+	// message to be replied to
+	Quote string `json:"quote,omitempty"`
+
+	// Optional. Mode for parsing entities in the quote
+	QuoteParseMode ParseMode `json:"quote_parse_mode,omitempty"`
+
+	// Optional. Special entities that appear in the quote. It can be specified
+	// instead of QuoteParseMode
+	QuoteEntities []MessageEntity `json:"quote_entities,omitempty"`
+
+	// Optional. Position of the quote in the original message in UTF-16 code
+	// units
+	QuotePosition int `json:"quote_position,omitempty"`
+}
+
+// LinkPreviewOptions describes the options used for link preview generation.
+type LinkPreviewOptions struct {
+	// Optional. True, if the link preview is disabled
+	IsDisabled bool `json:"is_disabled,omitempty"`
+
+	// Optional. URL to use for the link preview. If empty, then the first URL
+	// found in the message text will be used
+	URL string `json:"url,omitempty"`
+
+	// Optional. True, if the media in the link preview is supposed to be
+	// shrunk
+	PreferSmallMedia bool `json:"prefer_small_media,omitempty"`
+
+	// Optional. True, if the media in the link preview is supposed to be
+	// enlarged
+	PreferLargeMedia bool `json:"prefer_large_media,omitempty"`
+
+	// Optional. True, if the link preview must be shown above the message
+	// text. Otherwise, it is shown below the message text
+	ShowAboveText bool `json:"show_above_text,omitempty"`
+}
+
 // KeyboardButton represents one button of the reply keyboard. For simple text
 // buttons. Optional fields are mutually exclusive.
-// TODO(ig):
-type keyboardButton struct {
+type KeyboardButton struct {
 	// Text of the button. If none of the optional fields are used, it will be
 	// sent to the bot as a message when the button is pressed
 	Text string `json:"text"`
@@ -500,6 +1311,56 @@ type keyboardButton struct {
 	// Optional. If True, the user's current location will be sent when the button
 	// is pressed. Available in private chats only
 	RequestLocation bool `json:"request_location,omitempty"`
+
+	// Optional. If specified, the user will be asked to create a poll and send
+	// it to the bot when the button is pressed. Available in private chats
+	// only
+	RequestPoll *KeyboardButtonPollType `json:"request_poll,omitempty"`
+
+	// Optional. If specified, the described Web App will be launched when the
+	// button is pressed. The Web App will be able to send a WebAppData
+	// service message. Available in private chats only
+	WebApp *WebAppInfo `json:"web_app,omitempty"`
+}
+
+// KeyboardButtonPollType represents type of a poll, which is allowed to be
+// created and sent when the corresponding button is pressed.
+type KeyboardButtonPollType struct {
+	// If PollQuiz is passed, the user will be allowed to create only polls in
+	// the quiz mode. If PollRegular is passed, only regular polls will be
+	// allowed. Otherwise, the user will be allowed to create a poll of any
+	// type
+	Type PollType `json:"type,omitempty"`
+}
+
+// InlineKeyboardButton represents one button of an inline keyboard. Exactly
+// one of the optional fields must be used.
+type InlineKeyboardButton struct {
+	// Label text on the button
+	Text string `json:"text"`
+
+	// Optional. HTTP or tg:// URL to be opened when the button is pressed
+	URL string `json:"url,omitempty"`
+
+	// Optional. Data to be sent in a callback query to the bot when the
+	// button is pressed, 1-64 bytes
+	CallbackData string `json:"callback_data,omitempty"`
+
+	// Optional. Description of the Web App that will be launched when the
+	// user presses the button. The Web App will be able to send an arbitrary
+	// message on behalf of the user using AnswerWebAppQuery. Available only in
+	// private chats between a user and the bot
+	WebApp *WebAppInfo `json:"web_app,omitempty"`
+
+	// Optional. Description of the game that will be launched when the user
+	// presses the button. This type of button must always be the first button
+	// in the first row
+	CallbackGame *CallbackGame `json:"callback_game,omitempty"`
+
+	// Optional. Specify true to send a Pay button. This type of button must
+	// always be the first button in the first row and can only be used in
+	// invoice messages
+	Pay bool `json:"pay,omitempty"`
 }
 
 // ReplyKeyboard represent the removal of already sent keyboard markup. Upon
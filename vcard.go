@@ -0,0 +1,319 @@
+package telegram
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// VCard is a minimal vCard 3.0/4.0 (RFC 2426, RFC 6350) representation of a
+// contact. It can be attached to a Contact via its VCard field.
+type VCard struct {
+	// vCard version, either "3.0" or "4.0". Defaults to "3.0" when encoding
+	Version string
+
+	// Formatted name of the contact
+	FormattedName string
+
+	// Name components
+	FirstName  string
+	LastName   string
+	MiddleName string
+	Prefix     string
+	Suffix     string
+
+	// Organization name
+	Organization string
+
+	// Job title
+	Title string
+
+	// Phone numbers
+	Phones []VCardValue
+
+	// Email addresses
+	Emails []VCardValue
+
+	// URL of a web page of the contact
+	URL string
+
+	// Note about the contact
+	Note string
+}
+
+// VCardValue is a typed vCard value, such as a phone number or an email
+// address.
+type VCardValue struct {
+	// Types of the value, such as "cell", "work" or "home", in lower case
+	Types []string
+
+	// The value itself
+	Value string
+}
+
+// vcardLineLen is the maximum line length in octets, excluding the line break.
+const vcardLineLen = 75
+
+// String encodes the vCard. The result can be used as the VCard field of a
+// Contact.
+func (v VCard) String() string {
+	version := v.Version
+	if version == "" {
+		version = "3.0"
+	}
+
+	fn := v.FormattedName
+	if fn == "" {
+		fn = strings.TrimSpace(strings.Join([]string{v.Prefix, v.FirstName, v.MiddleName, v.LastName, v.Suffix}, " "))
+		fn = strings.Join(strings.Fields(fn), " ")
+	}
+
+	var buf strings.Builder
+	writeLine := func(name, value string) {
+		writeVCardLine(&buf, name+":"+value)
+	}
+
+	writeLine("BEGIN", "VCARD")
+	writeLine("VERSION", version)
+	writeLine("FN", escapeVCard(fn))
+	writeLine("N", strings.Join([]string{
+		escapeVCard(v.LastName),
+		escapeVCard(v.FirstName),
+		escapeVCard(v.MiddleName),
+		escapeVCard(v.Prefix),
+		escapeVCard(v.Suffix),
+	}, ";"))
+	if v.Organization != "" {
+		writeLine("ORG", escapeVCard(v.Organization))
+	}
+	if v.Title != "" {
+		writeLine("TITLE", escapeVCard(v.Title))
+	}
+	for _, p := range v.Phones {
+		writeLine("TEL"+vcardTypeParam(p.Types), escapeVCard(p.Value))
+	}
+	for _, e := range v.Emails {
+		writeLine("EMAIL"+vcardTypeParam(e.Types), escapeVCard(e.Value))
+	}
+	if v.URL != "" {
+		writeLine("URL", v.URL)
+	}
+	if v.Note != "" {
+		writeLine("NOTE", escapeVCard(v.Note))
+	}
+	writeLine("END", "VCARD")
+	return buf.String()
+}
+
+// ParseVCard parses a single vCard, such as the VCard field of a received
+// Contact. Properties which are not represented by VCard are ignored.
+func ParseVCard(s string) (VCard, error) {
+	var v VCard
+	var begin, end bool
+	for _, line := range unfoldVCard(s) {
+		if line == "" {
+			continue
+		}
+		if end {
+			return VCard{}, errors.New("vcard: content after END:VCARD")
+		}
+
+		name, params, value, err := splitVCardLine(line)
+		if err != nil {
+			return VCard{}, err
+		}
+
+		if !begin {
+			if name != "BEGIN" || !strings.EqualFold(value, "VCARD") {
+				return VCard{}, errors.New("vcard: missing BEGIN:VCARD")
+			}
+			begin = true
+			continue
+		}
+
+		switch name {
+		case "END":
+			end = true
+		case "VERSION":
+			v.Version = value
+		case "FN":
+			v.FormattedName = unescapeVCard(value)
+		case "N":
+			parts := splitVCardValue(value, ';')
+			for len(parts) < 5 {
+				parts = append(parts, "")
+			}
+			v.LastName = unescapeVCard(parts[0])
+			v.FirstName = unescapeVCard(parts[1])
+			v.MiddleName = unescapeVCard(parts[2])
+			v.Prefix = unescapeVCard(parts[3])
+			v.Suffix = unescapeVCard(parts[4])
+		case "ORG":
+			v.Organization = unescapeVCard(splitVCardValue(value, ';')[0])
+		case "TITLE":
+			v.Title = unescapeVCard(value)
+		case "TEL":
+			value = strings.TrimPrefix(unescapeVCard(value), "tel:")
+			v.Phones = append(v.Phones, VCardValue{Types: vcardTypes(params), Value: value})
+		case "EMAIL":
+			v.Emails = append(v.Emails, VCardValue{Types: vcardTypes(params), Value: unescapeVCard(value)})
+		case "URL":
+			v.URL = unescapeVCard(value)
+		case "NOTE":
+			v.Note = unescapeVCard(value)
+		}
+	}
+
+	if !begin {
+		return VCard{}, errors.New("vcard: missing BEGIN:VCARD")
+	}
+	if !end {
+		return VCard{}, errors.New("vcard: missing END:VCARD")
+	}
+	return v, nil
+}
+
+// writeVCardLine writes line to buf followed by a CRLF, folding it into
+// multiple lines if it is longer than 75 octets. Multi-octet UTF-8 sequences
+// are never split, unless line is not valid UTF-8.
+func writeVCardLine(buf *strings.Builder, line string) {
+	limit := vcardLineLen
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+		if i == 0 {
+			// not valid UTF-8, there is no rune to keep whole.
+			i = limit
+		}
+		buf.WriteString(line[:i])
+		buf.WriteString("\r\n ")
+		line = line[i:]
+		// continuation lines start with a space, which counts towards the
+		// limit.
+		limit = vcardLineLen - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+// unfoldVCard splits s into logical lines, joining folded lines.
+func unfoldVCard(s string) []string {
+	s = strings.Replace(s, "\r\n", "\n", -1)
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if len(lines) > 0 && (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+// splitVCardLine splits a content line into its upper-cased property name,
+// parameters and raw value. Property groups are dropped.
+func splitVCardLine(line string) (name string, params []string, value string, err error) {
+	quoted := false
+	colon := -1
+	for i := 0; i < len(line); i++ {
+		if line[i] == '"' {
+			quoted = !quoted
+		} else if line[i] == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", errors.New("vcard: malformed line: " + line)
+	}
+
+	fields := splitVCardParams(line[:colon])
+	name = strings.ToUpper(fields[0])
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name, fields[1:], line[colon+1:], nil
+}
+
+// splitVCardParams splits the name and parameters part of a content line on
+// semicolons which are not quoted.
+func splitVCardParams(s string) []string {
+	var fields []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				fields = append(fields, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(fields, s[start:])
+}
+
+// splitVCardValue splits a structured value on sep, ignoring escaped
+// separators.
+func splitVCardValue(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// vcardTypes returns the lower-cased types given in params. Both TYPE=a,b
+// parameters and vCard 3.0 style bare types are recognized.
+func vcardTypes(params []string) []string {
+	var types []string
+	for _, p := range params {
+		var value string
+		if i := strings.Index(p, "="); i >= 0 {
+			if !strings.EqualFold(p[:i], "TYPE") {
+				continue
+			}
+			value = p[i+1:]
+		} else {
+			value = p
+		}
+		value = strings.Trim(value, `"`)
+		for _, t := range strings.Split(value, ",") {
+			if t != "" {
+				types = append(types, strings.ToLower(t))
+			}
+		}
+	}
+	return types
+}
+
+// vcardTypeParam returns the TYPE parameter for types, including the leading
+// semicolon, or an empty string if there are no types.
+func vcardTypeParam(types []string) string {
+	if len(types) == 0 {
+		return ""
+	}
+	return ";TYPE=" + strings.Join(types, ",")
+}
+
+var (
+	vcardEscaper   = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`)
+	vcardUnescaper = strings.NewReplacer(`\\`, `\`, `\,`, ",", `\;`, ";", `\n`, "\n", `\N`, "\n")
+)
+
+// escapeVCard escapes a text value.
+func escapeVCard(s string) string { return vcardEscaper.Replace(s) }
+
+// unescapeVCard unescapes a text value.
+func unescapeVCard(s string) string { return vcardUnescaper.Replace(s) }
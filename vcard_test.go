@@ -0,0 +1,188 @@
+package telegram
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestVCardRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		vcard VCard
+	}{
+		{
+			name: "minimal",
+			vcard: VCard{
+				Version:       "3.0",
+				FormattedName: "Jane Doe",
+				FirstName:     "Jane",
+				LastName:      "Doe",
+			},
+		},
+		{
+			name: "full",
+			vcard: VCard{
+				Version:       "4.0",
+				FormattedName: "Dr. Jane Q. Doe Jr.",
+				FirstName:     "Jane",
+				LastName:      "Doe",
+				MiddleName:    "Q.",
+				Prefix:        "Dr.",
+				Suffix:        "Jr.",
+				Organization:  "Example Inc.",
+				Title:         "Engineer",
+				Phones: []VCardValue{
+					{Types: []string{"cell"}, Value: "+905551234567"},
+					{Types: []string{"work", "voice"}, Value: "+902121234567"},
+					{Value: "+15555550100"},
+				},
+				Emails: []VCardValue{
+					{Types: []string{"home"}, Value: "jane@example.com"},
+				},
+				URL:  "https://example.com/jane",
+				Note: "likes tea",
+			},
+		},
+		{
+			name: "escaping",
+			vcard: VCard{
+				Version:       "3.0",
+				FormattedName: `Doe, Jane; "JD" \ Esq.`,
+				FirstName:     "Jane;Mary",
+				LastName:      "Doe,Smith",
+				Organization:  `A\B; C, D`,
+				Note:          "first line\nsecond, line; with \\ backslash\nthird",
+			},
+		},
+		{
+			name: "folding",
+			vcard: VCard{
+				Version:       "4.0",
+				FormattedName: "Çağrı Gündoğdu",
+				FirstName:     "Çağrı",
+				LastName:      "Gündoğdu",
+				Note:          strings.Repeat("ğüşiöç 😀 ", 30),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.vcard.String()
+			got, err := ParseVCard(s)
+			if err != nil {
+				t.Fatalf("ParseVCard(%q): %v", s, err)
+			}
+			if !reflect.DeepEqual(got, tt.vcard) {
+				t.Errorf("ParseVCard(%q) =\n%+v, want\n%+v", s, got, tt.vcard)
+			}
+		})
+	}
+}
+
+func TestVCardString(t *testing.T) {
+	v := VCard{
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Prefix:    "Dr.",
+		Phones:    []VCardValue{{Types: []string{"cell", "voice"}, Value: "+905551234567"}},
+		Emails:    []VCardValue{{Value: "jane@example.com"}},
+		Note:      "a,b;c\\d\ne",
+	}
+	want := "BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"FN:Dr. Jane Doe\r\n" +
+		"N:Doe;Jane;;Dr.;\r\n" +
+		"TEL;TYPE=cell,voice:+905551234567\r\n" +
+		"EMAIL:jane@example.com\r\n" +
+		`NOTE:a\,b\;c\\d\ne` + "\r\n" +
+		"END:VCARD\r\n"
+	if got := v.String(); got != want {
+		t.Errorf("String() =\n%q, want\n%q", got, want)
+	}
+
+	v.Version = "4.0"
+	want = strings.Replace(want, "VERSION:3.0", "VERSION:4.0", 1)
+	if got := v.String(); got != want {
+		t.Errorf("String() with version 4.0 =\n%q, want\n%q", got, want)
+	}
+}
+
+func TestVCardFolding(t *testing.T) {
+	tests := []struct {
+		name string
+		note string
+	}{
+		{"ascii", strings.Repeat("abcdefghij", 20)},
+		{"two octets", strings.Repeat("ğ", 200)},
+		{"mixed", "x" + strings.Repeat("😀ş", 50)},
+		{"invalid utf-8", strings.Repeat("\x80", 200)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := VCard{Note: tt.note}.String()
+			for _, line := range strings.Split(strings.TrimSuffix(s, "\r\n"), "\r\n") {
+				if len(line) > vcardLineLen {
+					t.Errorf("line %q is %v octets, want at most %v", line, len(line), vcardLineLen)
+				}
+				if utf8.ValidString(tt.note) && !utf8.ValidString(line) {
+					t.Errorf("line %q splits a rune", line)
+				}
+			}
+
+			v, err := ParseVCard(s)
+			if err != nil {
+				t.Fatalf("ParseVCard: %v", err)
+			}
+			if v.Note != tt.note {
+				t.Errorf("Note = %q, want %q", v.Note, tt.note)
+			}
+		})
+	}
+}
+
+func TestParseVCard(t *testing.T) {
+	s := "BEGIN:VCARD\n" +
+		"VERSION:3.0\n" +
+		"item1.FN:Jane\n" +
+		" Doe\n" +
+		"n:Doe;Jane\n" +
+		"TEL;CELL;VOICE:+905551234567\n" +
+		"TEL;TYPE=\"WORK,fax\":tel:+902121234567\n" +
+		"EMAIL;PREF=1;TYPE=home:jane@example.com\n" +
+		"ORG:Example\\, Inc.;R&D\n" +
+		"X-UNKNOWN:ignored\n" +
+		"END:VCARD\n"
+	want := VCard{
+		Version:       "3.0",
+		FormattedName: "JaneDoe",
+		FirstName:     "Jane",
+		LastName:      "Doe",
+		Organization:  "Example, Inc.",
+		Phones: []VCardValue{
+			{Types: []string{"cell", "voice"}, Value: "+905551234567"},
+			{Types: []string{"work", "fax"}, Value: "+902121234567"},
+		},
+		Emails: []VCardValue{{Types: []string{"home"}, Value: "jane@example.com"}},
+	}
+	got, err := ParseVCard(s)
+	if err != nil {
+		t.Fatalf("ParseVCard: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseVCard =\n%+v, want\n%+v", got, want)
+	}
+
+	for _, bad := range []string{
+		"",
+		"VERSION:3.0\nEND:VCARD\n",
+		"BEGIN:VCARD\nFN:Jane\n",
+		"BEGIN:VCARD\nno colon\nEND:VCARD\n",
+		"BEGIN:VCARD\nEND:VCARD\nFN:Jane\n",
+	} {
+		if _, err := ParseVCard(bad); err == nil {
+			t.Errorf("ParseVCard(%q) = nil error, want error", bad)
+		}
+	}
+}
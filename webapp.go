@@ -0,0 +1,359 @@
+package telegram
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Public keys Telegram signs Web App init data with. They are kept as arrays,
+// so that they can't be modified through the copies returned by
+// WebAppPublicKey and WebAppTestPublicKey.
+var (
+	webAppPublicKey     = mustDecodePublicKey("e7bf03a2fa4602af4580703d88dda5bb59f32ed8b02a56c187fe7d34caed242d")
+	webAppTestPublicKey = mustDecodePublicKey("40055058a4ee38156a06562e52eece92a771bcd8346a8c4615cb7376eddf72ec")
+)
+
+// WebAppPublicKey returns the public key Telegram signs Web App init data
+// with, for validating init data by third parties which don't have the bot
+// token. See ValidateWebAppInitDataSignature.
+func WebAppPublicKey() ed25519.PublicKey {
+	k := webAppPublicKey
+	return k[:]
+}
+
+// WebAppTestPublicKey returns the public key Telegram signs Web App init data
+// with in the test environment.
+func WebAppTestPublicKey() ed25519.PublicKey {
+	k := webAppTestPublicKey
+	return k[:]
+}
+
+// WebAppUser represents a user in the init data of a Web App.
+type WebAppUser struct {
+	// Unique identifier for the user or bot
+	ID int64 `json:"id"`
+
+	// True, if the user is a bot. Returns in the Receiver field only
+	IsBot bool `json:"is_bot,omitempty"`
+
+	// First name of the user or bot
+	FirstName string `json:"first_name"`
+
+	// Optional. Last name of the user or bot
+	LastName string `json:"last_name,omitempty"`
+
+	// Optional. Username of the user or bot
+	Username string `json:"username,omitempty"`
+
+	// Optional. IETF language tag of the user's language. Returns in the User
+	// field only
+	LanguageCode string `json:"language_code,omitempty"`
+
+	// Optional. True, if the user is a Telegram Premium user
+	IsPremium bool `json:"is_premium,omitempty"`
+
+	// Optional. True, if the user added the bot to the attachment menu
+	AddedToAttachmentMenu bool `json:"added_to_attachment_menu,omitempty"`
+
+	// Optional. True, if the user allowed the bot to message them
+	AllowsWriteToPM bool `json:"allows_write_to_pm,omitempty"`
+
+	// Optional. URL of the user's profile photo, in .jpeg or .svg format
+	PhotoURL string `json:"photo_url,omitempty"`
+}
+
+// WebAppChat represents a chat in the init data of a Web App.
+type WebAppChat struct {
+	// Unique identifier for the chat
+	ID int64 `json:"id"`
+
+	// Type of the chat, can be either “group”, “supergroup” or “channel”
+	Type string `json:"type"`
+
+	// Title of the chat
+	Title string `json:"title"`
+
+	// Optional. Username of the chat
+	Username string `json:"username,omitempty"`
+
+	// Optional. URL of the chat's photo, in .jpeg or .svg format
+	PhotoURL string `json:"photo_url,omitempty"`
+}
+
+// WebAppData represents the data sent from a Web App to the bot, when the
+// Web App is launched from a reply keyboard button.
+type WebAppData struct {
+	// The data. Be aware that a bad client can send arbitrary data in this
+	// field
+	Data string `json:"data"`
+
+	// Text of the web_app keyboard button from which the Web App was opened.
+	// Be aware that a bad client can send arbitrary data in this field
+	ButtonText string `json:"button_text"`
+}
+
+// WebAppInitData represents the data transferred to a Web App when it is
+// opened.
+type WebAppInitData struct {
+	// Optional. A unique identifier for the Web App session, required for
+	// sending messages via AnswerWebAppQuery
+	QueryID string
+
+	// Optional. The user who opened the Web App
+	User *WebAppUser
+
+	// Optional. The chat partner of the current user in the chat where the
+	// bot was launched via the attachment menu
+	Receiver *WebAppUser
+
+	// Optional. The chat where the bot was launched via the attachment menu
+	Chat *WebAppChat
+
+	// Optional. Type of the chat from which the Web App was opened, can be
+	// either “sender” for a private chat with the user opening the link,
+	// “private”, “group”, “supergroup” or “channel”
+	ChatType string
+
+	// Optional. Global identifier, uniquely corresponding to the chat from
+	// which the Web App was opened
+	ChatInstance string
+
+	// Optional. The value of the startattach parameter, passed via link
+	StartParam string
+
+	// Optional. Time in seconds, after which a message can be sent via
+	// AnswerWebAppQuery
+	CanSendAfter int
+
+	// Time when the form was opened in Unix time
+	AuthUnixtime int64
+
+	// A hash of all passed parameters, which the bot server can use to check
+	// their validity
+	Hash string
+
+	// A signature of all passed parameters except Hash, which third parties
+	// can use to check their validity
+	Signature string
+}
+
+// AuthTime returns the moment the Web App was opened in UTC time.
+func (d WebAppInitData) AuthTime() time.Time {
+	return time.Unix(d.AuthUnixtime, 0).UTC()
+}
+
+// ParseWebAppInitData parses the init data query string passed to a Web App
+// by Telegram, available as Telegram.WebApp.initData in the Web App. The data
+// is not validated, see ValidateWebAppInitData.
+func ParseWebAppInitData(initData string) (WebAppInitData, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return WebAppInitData{}, err
+	}
+	return parseWebAppInitData(values)
+}
+
+// ValidateWebAppInitData parses the init data passed to a Web App like
+// ParseWebAppInitData, and validates that it was sent by Telegram to the bot
+// with the given token, by checking its HMAC-SHA256 hash. If maxAge is
+// positive, the data is rejected if it is older than maxAge, which protects
+// against reuse of leaked init data.
+func ValidateWebAppInitData(token, initData string, maxAge time.Duration) (WebAppInitData, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return WebAppInitData{}, err
+	}
+
+	hash, err := hex.DecodeString(values.Get("hash"))
+	if err != nil || len(hash) == 0 {
+		return WebAppInitData{}, errors.New("webapp: missing or malformed hash")
+	}
+
+	secret := hmacSHA256([]byte("WebAppData"), []byte(token))
+	expected := hmacSHA256(secret, []byte(webAppDataCheckString(values, "hash")))
+	if !hmac.Equal(hash, expected) {
+		return WebAppInitData{}, errors.New("webapp: invalid hash")
+	}
+
+	return validateWebAppInitData(values, maxAge)
+}
+
+// ValidateWebAppInitDataSignature parses the init data passed to a Web App
+// like ParseWebAppInitData, and validates that it was sent by Telegram to the
+// bot with the given ID, by checking its Ed25519 signature with the given
+// public key. It is meant for third parties which don't have the bot token.
+// publicKey is WebAppPublicKey(), or WebAppTestPublicKey() in the test
+// environment. If maxAge is positive, the data is rejected if it is older
+// than maxAge.
+func ValidateWebAppInitDataSignature(botID int64, initData string, publicKey ed25519.PublicKey, maxAge time.Duration) (WebAppInitData, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return WebAppInitData{}, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(values.Get("signature"), "="))
+	if err != nil || len(signature) == 0 {
+		return WebAppInitData{}, errors.New("webapp: missing or malformed signature")
+	}
+
+	if len(publicKey) != ed25519.PublicKeySize {
+		return WebAppInitData{}, errors.New("webapp: invalid public key")
+	}
+
+	data := strconv.FormatInt(botID, 10) + ":WebAppData\n" + webAppDataCheckString(values, "hash", "signature")
+	if !ed25519.Verify(publicKey, []byte(data), signature) {
+		return WebAppInitData{}, errors.New("webapp: invalid signature")
+	}
+
+	return validateWebAppInitData(values, maxAge)
+}
+
+// validateWebAppInitData parses the authenticated init data and checks its
+// freshness.
+func validateWebAppInitData(values url.Values, maxAge time.Duration) (WebAppInitData, error) {
+	d, err := parseWebAppInitData(values)
+	if err != nil {
+		return WebAppInitData{}, err
+	}
+
+	if d.AuthUnixtime == 0 {
+		return WebAppInitData{}, errors.New("webapp: missing auth_date")
+	}
+
+	if maxAge > 0 && time.Since(d.AuthTime()) > maxAge {
+		return WebAppInitData{}, fmt.Errorf("webapp: init data is older than %v", maxAge)
+	}
+
+	return d, nil
+}
+
+func parseWebAppInitData(values url.Values) (WebAppInitData, error) {
+	d := WebAppInitData{
+		QueryID:      values.Get("query_id"),
+		ChatType:     values.Get("chat_type"),
+		ChatInstance: values.Get("chat_instance"),
+		StartParam:   values.Get("start_param"),
+		Hash:         values.Get("hash"),
+		Signature:    values.Get("signature"),
+	}
+
+	var err error
+	if v := values.Get("auth_date"); v != "" {
+		d.AuthUnixtime, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return WebAppInitData{}, fmt.Errorf("webapp: malformed auth_date: %v", err)
+		}
+	}
+
+	if v := values.Get("can_send_after"); v != "" {
+		d.CanSendAfter, err = strconv.Atoi(v)
+		if err != nil {
+			return WebAppInitData{}, fmt.Errorf("webapp: malformed can_send_after: %v", err)
+		}
+	}
+
+	if v := values.Get("user"); v != "" {
+		d.User = &WebAppUser{}
+		if err := json.Unmarshal([]byte(v), d.User); err != nil {
+			return WebAppInitData{}, fmt.Errorf("webapp: malformed user: %v", err)
+		}
+	}
+
+	if v := values.Get("receiver"); v != "" {
+		d.Receiver = &WebAppUser{}
+		if err := json.Unmarshal([]byte(v), d.Receiver); err != nil {
+			return WebAppInitData{}, fmt.Errorf("webapp: malformed receiver: %v", err)
+		}
+	}
+
+	if v := values.Get("chat"); v != "" {
+		d.Chat = &WebAppChat{}
+		if err := json.Unmarshal([]byte(v), d.Chat); err != nil {
+			return WebAppInitData{}, fmt.Errorf("webapp: malformed chat: %v", err)
+		}
+	}
+
+	return d, nil
+}
+
+// webAppDataCheckString returns the string the init data hash and signature
+// are computed over: the fields except the excluded ones, sorted by key, in
+// the format key=value and separated by newlines.
+func webAppDataCheckString(values url.Values, exclude ...string) string {
+	var fields []string
+	for k, vs := range values {
+		excluded := false
+		for _, e := range exclude {
+			if k == e {
+				excluded = true
+				break
+			}
+		}
+		if excluded || len(vs) == 0 {
+			continue
+		}
+		fields = append(fields, k+"="+vs[0])
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, "\n")
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func mustDecodePublicKey(s string) [ed25519.PublicKeySize]byte {
+	var k [ed25519.PublicKeySize]byte
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(k) {
+		panic("webapp: malformed public key " + s)
+	}
+	copy(k[:], b)
+	return k
+}
+
+// AnswerWebAppQuery sets the result of an interaction with a Web App and
+// sends a corresponding message on behalf of the user to the chat from which
+// the query originated. queryID is the QueryID of the Web App init data. The
+// identifier of the sent inline message is returned.
+func (b *Bot) AnswerWebAppQuery(queryID string, result InlineQueryResult) (string, error) {
+	const method = "answerWebAppQuery"
+	params := url.Values{}
+	params.Set("web_app_query_id", queryID)
+
+	res, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	params.Set("result", string(res))
+
+	var r struct {
+		response
+		Message struct {
+			InlineMessageID string `json:"inline_message_id"`
+		} `json:"result"`
+	}
+	err = b.sendCommand(nil, method, params, &r)
+	if err != nil {
+		return "", err
+	}
+
+	if !r.OK {
+		return "", fmt.Errorf("%v (%v)", r.Desc, r.ErrCode)
+	}
+
+	return r.Message.InlineMessageID, nil
+}
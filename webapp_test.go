@@ -0,0 +1,211 @@
+package telegram
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testWebAppToken = "123456:ABC-DEF"
+
+	// testWebAppInitData is signed with testWebAppToken.
+	testWebAppInitData = "query_id=AAHdF6IQAAAAAN0XohDhrOrc" +
+		"&user=%7B%22id%22%3A279058397%2C%22first_name%22%3A%22Vladislav%22%2C%22last_name%22%3A%22Kibenko%22%2C%22username%22%3A%22vdkfrost%22%2C%22language_code%22%3A%22ru%22%2C%22is_premium%22%3Atrue%7D" +
+		"&auth_date=1700000000&chat_type=sender&chat_instance=-4108323464321938271" +
+		"&hash=19d52d3264feba42dd3dbb4b245128cb315366f5aaefdf1090431ed6d46dfaed"
+)
+
+func TestValidateWebAppInitData(t *testing.T) {
+	d, err := ValidateWebAppInitData(testWebAppToken, testWebAppInitData, 0)
+	if err != nil {
+		t.Fatalf("ValidateWebAppInitData: %v", err)
+	}
+	if d.QueryID != "AAHdF6IQAAAAAN0XohDhrOrc" || d.ChatType != "sender" || d.ChatInstance != "-4108323464321938271" {
+		t.Errorf("ValidateWebAppInitData = %+v", d)
+	}
+	if d.User == nil || d.User.ID != 279058397 || d.User.FirstName != "Vladislav" || !d.User.IsPremium {
+		t.Errorf("ValidateWebAppInitData user = %+v", d.User)
+	}
+	if got, want := d.AuthTime(), time.Unix(1700000000, 0).UTC(); !got.Equal(want) {
+		t.Errorf("AuthTime() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		initData string
+		maxAge   time.Duration
+	}{
+		{
+			name:     "tampered field",
+			token:    testWebAppToken,
+			initData: strings.Replace(testWebAppInitData, "Vladislav", "Vladimir", 1),
+		},
+		{
+			name:     "added field",
+			token:    testWebAppToken,
+			initData: testWebAppInitData + "&start_param=x",
+		},
+		{
+			name:     "wrong token",
+			token:    "123456:ABC-DEG",
+			initData: testWebAppInitData,
+		},
+		{
+			name:     "expired",
+			token:    testWebAppToken,
+			initData: testWebAppInitData,
+			maxAge:   24 * time.Hour,
+		},
+		{
+			name:     "missing hash",
+			token:    testWebAppToken,
+			initData: testWebAppInitData[:strings.Index(testWebAppInitData, "&hash=")],
+		},
+		{
+			name:     "malformed hash",
+			token:    testWebAppToken,
+			initData: testWebAppInitData + "zz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ValidateWebAppInitData(tt.token, tt.initData, tt.maxAge); err == nil {
+				t.Errorf("ValidateWebAppInitData(%q) = nil error, want error", tt.initData)
+			}
+		})
+	}
+}
+
+func TestValidateWebAppInitDataSignature(t *testing.T) {
+	const botID = 123456
+	priv := ed25519.NewKeyFromSeed([]byte("0123456789abcdef0123456789abcdef"))
+	pub := priv.Public().(ed25519.PublicKey)
+
+	sign := func(values url.Values) string {
+		var fields []string
+		for k := range values {
+			if k != "hash" && k != "signature" {
+				fields = append(fields, k+"="+values.Get(k))
+			}
+		}
+		sort.Strings(fields)
+		data := strconv.Itoa(botID) + ":WebAppData\n" + strings.Join(fields, "\n")
+		return base64.RawURLEncoding.EncodeToString(ed25519.Sign(priv, []byte(data)))
+	}
+
+	values := url.Values{}
+	values.Set("query_id", "AAHdF6IQAAAAAN0XohDhrOrc")
+	values.Set("user", `{"id":279058397,"first_name":"Vladislav"}`)
+	values.Set("auth_date", strconv.FormatInt(time.Now().Unix(), 10))
+	values.Set("hash", "19d52d3264feba42dd3dbb4b245128cb315366f5aaefdf1090431ed6d46dfaed")
+	values.Set("signature", sign(values))
+	initData := values.Encode()
+
+	d, err := ValidateWebAppInitDataSignature(botID, initData, pub, time.Hour)
+	if err != nil {
+		t.Fatalf("ValidateWebAppInitDataSignature: %v", err)
+	}
+	if d.User == nil || d.User.ID != 279058397 || d.Signature != values.Get("signature") {
+		t.Errorf("ValidateWebAppInitDataSignature = %+v", d)
+	}
+
+	expired := url.Values{}
+	for k, v := range values {
+		expired[k] = v
+	}
+	expired.Set("auth_date", strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10))
+	expired.Set("signature", sign(expired))
+
+	missing := url.Values{}
+	for k, v := range values {
+		if k != "signature" {
+			missing[k] = v
+		}
+	}
+
+	tests := []struct {
+		name      string
+		botID     int64
+		initData  string
+		publicKey ed25519.PublicKey
+	}{
+		{
+			name:      "tampered field",
+			botID:     botID,
+			initData:  strings.Replace(initData, "Vladislav", "Vladimir", 1),
+			publicKey: pub,
+		},
+		{
+			name:      "wrong bot",
+			botID:     botID + 1,
+			initData:  initData,
+			publicKey: pub,
+		},
+		{
+			name:      "wrong key",
+			botID:     botID,
+			initData:  initData,
+			publicKey: WebAppPublicKey(),
+		},
+		{
+			name:      "invalid key",
+			botID:     botID,
+			initData:  initData,
+			publicKey: pub[:16],
+		},
+		{
+			name:      "expired",
+			botID:     botID,
+			initData:  expired.Encode(),
+			publicKey: pub,
+		},
+		{
+			name:      "missing signature",
+			botID:     botID,
+			initData:  missing.Encode(),
+			publicKey: pub,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ValidateWebAppInitDataSignature(tt.botID, tt.initData, tt.publicKey, time.Hour); err == nil {
+				t.Errorf("ValidateWebAppInitDataSignature(%q) = nil error, want error", tt.initData)
+			}
+		})
+	}
+}
+
+func TestWebAppPublicKey(t *testing.T) {
+	k := WebAppPublicKey()
+	if len(k) != ed25519.PublicKeySize {
+		t.Fatalf("WebAppPublicKey() is %v bytes, want %v", len(k), ed25519.PublicKeySize)
+	}
+	k[0] ^= 0xff
+	if bytes.Equal(WebAppPublicKey(), k) {
+		t.Errorf("WebAppPublicKey() changed through a returned copy")
+	}
+	if bytes.Equal(WebAppPublicKey(), WebAppTestPublicKey()) {
+		t.Errorf("WebAppPublicKey() = WebAppTestPublicKey()")
+	}
+}
+
+func TestInlineQueryResultsButtonWebApp(t *testing.T) {
+	b := InlineQueryResultsButton{Text: "Open", WebApp: &WebAppInfo{URL: "https://example.com/app"}}
+	got, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"text":"Open","web_app":{"url":"https://example.com/app"}}`
+	if string(got) != want {
+		t.Errorf("json.Marshal = %s, want %s", got, want)
+	}
+}